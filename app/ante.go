@@ -1,6 +1,7 @@
 package app
 
 import (
+	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -8,11 +9,22 @@ import (
 	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
 )
 
+// keys of per block state in the ante transient store
+var (
+	msgQuotaPrefix = []byte{0x01}
+	actFeesKey     = []byte{0x02}
+)
+
 // HandlerOptions extends the SDK's AnteHandler options
 type HandlerOptions struct {
 	ante.HandlerOptions
-	CDC       codec.BinaryCodec
-	GovKeeper *govkeeper.Keeper
+	CDC          codec.Codec
+	GovKeeper    *govkeeper.Keeper
+	MarketKeeper MarketParamsKeeper
+	OracleKeeper FeeOracleKeeper
+	BmeKeeper    FeeParamsKeeper
+	// AnteTKey is the transient store of per block marketplace message counters and ACT fees
+	AnteTKey storetypes.StoreKey
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -43,13 +55,30 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 		return nil, sdkerrors.ErrLogic.Wrap("akash feegrant keeper is required for ante builder")
 	}
 
+	if options.MarketKeeper == nil {
+		return nil, sdkerrors.ErrLogic.Wrap("akash market keeper is required for ante builder")
+	}
+
+	if options.OracleKeeper == nil {
+		return nil, sdkerrors.ErrLogic.Wrap("akash oracle keeper is required for ante builder")
+	}
+
+	if options.BmeKeeper == nil {
+		return nil, sdkerrors.ErrLogic.Wrap("akash bme keeper is required for ante builder")
+	}
+
+	if options.AnteTKey == nil {
+		return nil, sdkerrors.ErrLogic.Wrap("akash ante transient store key is required for ante builder")
+	}
+
 	anteDecorators := []sdk.AnteDecorator{
 		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 		ante.NewValidateBasicDecorator(),
 		ante.NewTxTimeoutHeightDecorator(),
 		ante.NewValidateMemoDecorator(options.AccountKeeper),
 		ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
-		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, nil),
+		NewMsgPolicyDecorator(options.CDC, options.MarketKeeper, options.AnteTKey), // reject marketplace spam before fees are deducted
+		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, NewACTTxFeeChecker(options.OracleKeeper, options.BmeKeeper, options.AnteTKey)),
 		ante.NewSetPubKeyDecorator(options.AccountKeeper), // SetPubKeyDecorator must be called before all signature verification decorators
 		ante.NewValidateSigCountDecorator(options.AccountKeeper),
 		ante.NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
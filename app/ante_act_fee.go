@@ -0,0 +1,149 @@
+package app
+
+import (
+	"math"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	"pkg.akt.dev/go/sdkutil"
+)
+
+// FeeOracleKeeper provides prices used to value ACT fees in AKT
+type FeeOracleKeeper interface {
+	GetAggregatedPrice(ctx sdk.Context, denom string) (sdkmath.LegacyDec, error)
+}
+
+// FeeParamsKeeper provides ACT fee premium and per block cap
+type FeeParamsKeeper interface {
+	GetParams(ctx sdk.Context) (bmetypes.Params, error)
+}
+
+// NewACTTxFeeChecker returns TxFeeChecker which, in addition to checks of the default one, accepts fees priced in ACT.
+// ACT fee is deducted and sent to the fee collector in uact as paid, it is not swapped. For AKT minimum gas prices
+// of the validator it is valued in AKT at oracle TWAP reduced by bme params fee_premium_bps. Total ACT fees valued
+// within a block are capped by bme params max_block_act_fees, and they are rejected while either price is stalled.
+// Zero cap disables valuation, ACT fee then counts only towards uact minimum gas prices, if the validator sets any.
+func NewACTTxFeeChecker(okeeper FeeOracleKeeper, bkeeper FeeParamsKeeper, tkey storetypes.StoreKey) ante.TxFeeChecker {
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return nil, 0, sdkerrors.ErrTxDecode.Wrap("Tx must be a FeeTx")
+		}
+
+		fee := feeTx.GetFee()
+		gas := feeTx.GetGas()
+
+		effectiveFee := fee
+
+		if actFee := fee.AmountOf(sdkutil.DenomUact); actFee.IsPositive() {
+			value, err := actFeeValue(ctx, okeeper, bkeeper, tkey, actFee)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if value.IsPositive() {
+				effectiveFee = effectiveFee.Add(sdk.NewCoin(sdkutil.DenomUakt, value))
+			}
+		}
+
+		// min gas prices are local to the validator, so they are checked only when tx enters the mempool
+		if ctx.IsCheckTx() {
+			minGasPrices := ctx.MinGasPrices()
+			if !minGasPrices.IsZero() {
+				requiredFees := make(sdk.Coins, len(minGasPrices))
+
+				glDec := sdkmath.LegacyNewDec(int64(gas))
+				for i, gp := range minGasPrices {
+					requiredFees[i] = sdk.NewCoin(gp.Denom, gp.Amount.Mul(glDec).Ceil().RoundInt())
+				}
+
+				if !effectiveFee.IsAnyGTE(requiredFees) {
+					return nil, 0, sdkerrors.ErrInsufficientFee.Wrapf("insufficient fees; got: %s required: %s", fee, requiredFees)
+				}
+			}
+		}
+
+		return fee, txPriority(fee, int64(gas)), nil
+	}
+}
+
+// actFeeValue returns value of ACT fee in uakt and accounts it towards the block cap.
+// Zero value is returned while the cap is not set.
+func actFeeValue(ctx sdk.Context, okeeper FeeOracleKeeper, bkeeper FeeParamsKeeper, tkey storetypes.StoreKey, actFee sdkmath.Int) (sdkmath.Int, error) {
+	params, err := bkeeper.GetParams(ctx)
+	if err != nil {
+		return sdkmath.Int{}, err
+	}
+
+	if params.MaxBlockActFees.IsNil() || params.MaxBlockActFees.IsZero() {
+		return sdkmath.ZeroInt(), nil
+	}
+
+	store := ctx.TransientStore(tkey)
+
+	total := actFee
+	if bz := store.Get(actFeesKey); bz != nil {
+		var prev sdkmath.Int
+		if err := prev.Unmarshal(bz); err != nil {
+			return sdkmath.Int{}, err
+		}
+
+		total = total.Add(prev)
+	}
+
+	if total.GT(params.MaxBlockActFees) {
+		return sdkmath.Int{}, sdkerrors.ErrInsufficientFee.Wrapf("ACT fees of the block exceed cap of %s%s", params.MaxBlockActFees, sdkutil.DenomUact)
+	}
+
+	priceACT, err := okeeper.GetAggregatedPrice(ctx, sdkutil.DenomUact)
+	if err != nil {
+		return sdkmath.Int{}, sdkerrors.ErrInsufficientFee.Wrapf("ACT fee cannot be valued: %s", err)
+	}
+
+	priceAKT, err := okeeper.GetAggregatedPrice(ctx, sdkutil.DenomUakt)
+	if err != nil {
+		return sdkmath.Int{}, sdkerrors.ErrInsufficientFee.Wrapf("ACT fee cannot be valued: %s", err)
+	}
+
+	if !priceAKT.IsPositive() {
+		return sdkmath.Int{}, sdkerrors.ErrInsufficientFee.Wrap("ACT fee cannot be valued: invalid AKT price")
+	}
+
+	// fee is accounted only once it is valued, the ante handler discards writes of rejected txs
+	bz, err := total.Marshal()
+	if err != nil {
+		return sdkmath.Int{}, err
+	}
+
+	store.Set(actFeesKey, bz)
+
+	discount := sdkmath.LegacyNewDec(10000 - int64(params.FeePremiumBps)).QuoInt64(10000)
+
+	return sdkmath.LegacyNewDecFromInt(actFee).Mul(priceACT).Quo(priceAKT).Mul(discount).TruncateInt(), nil
+}
+
+// txPriority returns priority of the tx as the smallest gas price among fee denoms,
+// same as the default TxFeeChecker does.
+func txPriority(fee sdk.Coins, gas int64) int64 {
+	var priority int64
+
+	for _, c := range fee {
+		p := int64(math.MaxInt64)
+
+		gasPrice := c.Amount.QuoRaw(gas)
+		if gasPrice.IsInt64() {
+			p = gasPrice.Int64()
+		}
+
+		if priority == 0 || p < priority {
+			priority = p
+		}
+	}
+
+	return priority
+}
@@ -0,0 +1,151 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	otypes "pkg.akt.dev/go/node/oracle/v2"
+	"pkg.akt.dev/go/sdkutil"
+
+	"pkg.akt.dev/node/v2/app"
+)
+
+type feeTx struct {
+	fee sdk.Coins
+	gas uint64
+}
+
+func (tx feeTx) GetMsgs() []sdk.Msg                    { return nil }
+func (tx feeTx) GetMsgsV2() ([]protov2.Message, error) { return nil, nil }
+func (tx feeTx) GetGas() uint64                        { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins                     { return tx.fee }
+func (tx feeTx) FeePayer() []byte                      { return nil }
+func (tx feeTx) FeeGranter() []byte                    { return nil }
+
+type feeOracle map[string]sdkmath.LegacyDec
+
+func (o feeOracle) GetAggregatedPrice(_ sdk.Context, denom string) (sdkmath.LegacyDec, error) {
+	price, found := o[denom]
+	if !found {
+		return sdkmath.LegacyDec{}, otypes.ErrPriceStalled
+	}
+
+	return price, nil
+}
+
+type feeParams bmetypes.Params
+
+func (p feeParams) GetParams(_ sdk.Context) (bmetypes.Params, error) {
+	return bmetypes.Params(p), nil
+}
+
+// checkFee runs the checker on cache context and commits its writes only on success, same as the ante handler
+func checkFee(ctx sdk.Context, checker ante.TxFeeChecker, tx sdk.Tx) (sdk.Coins, error) {
+	cctx, write := ctx.CacheContext()
+
+	fee, _, err := checker(cctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	write()
+
+	return fee, nil
+}
+
+func TestACTTxFeeChecker(t *testing.T) {
+	key := storetypes.NewKVStoreKey("test")
+	tkey := storetypes.NewTransientStoreKey("transient_test")
+
+	ctx := testutil.DefaultContext(key, tkey).
+		WithIsCheckTx(true).
+		WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdkutil.DenomUakt, sdkmath.LegacyMustNewDecFromStr("0.025"))))
+
+	oracle := feeOracle{
+		sdkutil.DenomUact: sdkmath.LegacyOneDec(),
+		sdkutil.DenomUakt: sdkmath.LegacyMustNewDecFromStr("2.0"),
+	}
+
+	params := feeParams{
+		FeePremiumBps:   1000,
+		MaxBlockActFees: sdkmath.NewInt(15000),
+	}
+
+	checker := app.NewACTTxFeeChecker(oracle, params, tkey)
+
+	// 200000 gas at 0.025uakt requires 5000uakt, 10000uact converts to 4500uakt
+	_, err := checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 10000)), gas: 200000})
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// 12000uact converts to 5400uakt, rejected tx above is not accounted towards the cap
+	fee, err := checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), fee)
+
+	// block already accounted 12000uact
+	_, err = checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000})
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// AKT fees are not affected by the cap
+	_, err = checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 5000)), gas: 200000})
+	require.NoError(t, err)
+
+	// cap is reset every block
+	ctx = testutil.DefaultContext(key, tkey).WithIsCheckTx(true).WithMinGasPrices(ctx.MinGasPrices())
+
+	_, err = checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000})
+	require.NoError(t, err)
+
+	// ACT fees are rejected while price is stalled and are not accounted
+	ctx = testutil.DefaultContext(key, tkey).WithIsCheckTx(true).WithMinGasPrices(ctx.MinGasPrices())
+	delete(oracle, sdkutil.DenomUakt)
+
+	_, err = checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000})
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	oracle[sdkutil.DenomUakt] = sdkmath.LegacyMustNewDecFromStr("2.0")
+
+	_, err = checkFee(ctx, checker, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000})
+	require.NoError(t, err)
+}
+
+func TestACTTxFeeCheckerNoCap(t *testing.T) {
+	key := storetypes.NewKVStoreKey("test")
+	tkey := storetypes.NewTransientStoreKey("transient_test")
+
+	ctx := testutil.DefaultContext(key, tkey).
+		WithIsCheckTx(true).
+		WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdkutil.DenomUakt, sdkmath.LegacyMustNewDecFromStr("0.025"))))
+
+	oracle := feeOracle{
+		sdkutil.DenomUact: sdkmath.LegacyOneDec(),
+		sdkutil.DenomUakt: sdkmath.LegacyMustNewDecFromStr("2.0"),
+	}
+
+	checker := app.NewACTTxFeeChecker(oracle, feeParams{MaxBlockActFees: sdkmath.ZeroInt()}, tkey)
+
+	tx := feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 12000)), gas: 200000}
+
+	// ACT fee is not valued in AKT without the cap
+	_, err := checkFee(ctx, checker, tx)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// it still satisfies uact minimum gas prices of the validator
+	_, err = checkFee(ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdkutil.DenomUact, sdkmath.LegacyMustNewDecFromStr("0.05")))), checker, tx)
+	require.NoError(t, err)
+
+	// and is not rejected in blocks
+	fee, err := checkFee(ctx.WithIsCheckTx(false), checker, tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.fee, fee)
+}
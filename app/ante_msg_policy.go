@@ -0,0 +1,163 @@
+package app
+
+import (
+	"encoding/binary"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+)
+
+// maxNestedMsgDepth limits how deep authz MsgExec messages are walked by the policy decorator
+const maxNestedMsgDepth = 6
+
+// MarketParamsKeeper provides marketplace message policies to the ante handler
+type MarketParamsKeeper interface {
+	GetParams(ctx sdk.Context) (mvbeta.Params, error)
+}
+
+// MsgPolicyDecorator enforces governance configured marketplace message policies: minimum fee
+// required for each message of given type and a limit of messages of given type an account may
+// send within a block. Message counters are kept in transient store and reset every block.
+// Messages nested in authz MsgExec are subject to the same policies as top level messages.
+// Minimum fees are checked against fee coins as paid, in denominations of the policy min_fee
+// (uakt). Fees paid in other denominations, such as uact, are not valued towards them.
+type MsgPolicyDecorator struct {
+	cdc     codec.Codec
+	mkeeper MarketParamsKeeper
+	tkey    storetypes.StoreKey
+}
+
+// NewMsgPolicyDecorator returns a decorator enforcing market params msg_policies
+func NewMsgPolicyDecorator(cdc codec.Codec, mkeeper MarketParamsKeeper, tkey storetypes.StoreKey) MsgPolicyDecorator {
+	return MsgPolicyDecorator{
+		cdc:     cdc,
+		mkeeper: mkeeper,
+		tkey:    tkey,
+	}
+}
+
+func (d MsgPolicyDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	params, err := d.mkeeper.GetParams(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	if len(params.MsgPolicies) == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	policies := make(map[string]mvbeta.MsgPolicy, len(params.MsgPolicies))
+	for _, policy := range params.MsgPolicies {
+		policies[policy.MsgTypeURL] = policy
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.ErrTxDecode.Wrap("Tx must be a FeeTx")
+	}
+
+	msgs, err := policyMsgs(tx.GetMsgs(), 0)
+	if err != nil {
+		return ctx, err
+	}
+
+	store := ctx.TransientStore(d.tkey)
+	required := sdk.NewCoins()
+
+	for _, msg := range msgs {
+		typeURL := sdk.MsgTypeURL(msg)
+
+		policy, found := policies[typeURL]
+		if !found {
+			continue
+		}
+
+		required = required.Add(policy.MinFee...)
+
+		if policy.MaxPerBlock == 0 {
+			continue
+		}
+
+		signers, _, err := d.cdc.GetMsgV1Signers(msg)
+		if err != nil {
+			return ctx, err
+		}
+
+		if len(signers) == 0 {
+			continue
+		}
+
+		key := msgQuotaKey(typeURL, signers[0])
+
+		var count uint32
+		if bz := store.Get(key); bz != nil {
+			count = binary.BigEndian.Uint32(bz)
+		}
+
+		count++
+		if count > policy.MaxPerBlock {
+			return ctx, sdkerrors.ErrInvalidRequest.Wrapf(
+				"account %s exceeded quota of %d %s messages per block",
+				sdk.AccAddress(signers[0]),
+				policy.MaxPerBlock,
+				typeURL,
+			)
+		}
+
+		bz := make([]byte, 4)
+		binary.BigEndian.PutUint32(bz, count)
+		store.Set(key, bz)
+	}
+
+	// fee is not known while simulating, the simulation is used to estimate it
+	if !simulate && !required.IsZero() && !feeTx.GetFee().IsAllGTE(required) {
+		return ctx, sdkerrors.ErrInsufficientFee.Wrapf("insufficient fees for marketplace messages, they must be paid in denominations of required fees; got: %s required: %s", feeTx.GetFee(), required)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// policyMsgs flattens messages of the tx along with messages nested in authz MsgExec,
+// so wrapping a message into MsgExec does not bypass its policy
+func policyMsgs(msgs []sdk.Msg, depth int) ([]sdk.Msg, error) {
+	if depth > maxNestedMsgDepth {
+		return nil, sdkerrors.ErrInvalidRequest.Wrapf("nested messages exceed max depth of %d", maxNestedMsgDepth)
+	}
+
+	res := make([]sdk.Msg, 0, len(msgs))
+
+	for _, msg := range msgs {
+		res = append(res, msg)
+
+		exec, ok := msg.(*authz.MsgExec)
+		if !ok {
+			continue
+		}
+
+		inner, err := exec.GetMessages()
+		if err != nil {
+			return nil, err
+		}
+
+		nested, err := policyMsgs(inner, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, nested...)
+	}
+
+	return res, nil
+}
+
+func msgQuotaKey(typeURL string, signer []byte) []byte {
+	key := append([]byte{}, msgQuotaPrefix...)
+	key = append(key, address.MustLengthPrefix([]byte(typeURL))...)
+	return append(key, address.MustLengthPrefix(signer)...)
+}
@@ -0,0 +1,170 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	"pkg.akt.dev/go/sdkutil"
+	atestutil "pkg.akt.dev/go/testutil"
+
+	akash "pkg.akt.dev/node/v2/app"
+)
+
+type msgsTx struct {
+	feeTx
+	msgs []sdk.Msg
+}
+
+func (tx msgsTx) GetMsgs() []sdk.Msg { return tx.msgs }
+
+type marketParams mvbeta.Params
+
+func (p marketParams) GetParams(_ sdk.Context) (mvbeta.Params, error) {
+	return mvbeta.Params(p), nil
+}
+
+func nextAnte(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+	return ctx, nil
+}
+
+func setupMsgPolicy(t *testing.T) (akash.MsgPolicyDecorator, func() sdk.Context) {
+	t.Helper()
+
+	encodingConfig := sdkutil.MakeEncodingConfig()
+	akash.ModuleBasics().RegisterInterfaces(encodingConfig.InterfaceRegistry)
+
+	key := storetypes.NewKVStoreKey("test")
+	tkey := storetypes.NewTransientStoreKey("transient_test")
+
+	params := marketParams{
+		MsgPolicies: []mvbeta.MsgPolicy{
+			{
+				MsgTypeURL:  sdk.MsgTypeURL(&banktypes.MsgSend{}),
+				MinFee:      sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 1000)),
+				MaxPerBlock: 2,
+			},
+		},
+	}
+
+	decorator := akash.NewMsgPolicyDecorator(encodingConfig.Codec, params, tkey)
+
+	// every new context comes with fresh transient store, same as a new block
+	newBlock := func() sdk.Context {
+		return testutil.DefaultContext(key, tkey)
+	}
+
+	return decorator, newBlock
+}
+
+func sendMsg(t *testing.T, from sdk.AccAddress) sdk.Msg {
+	return banktypes.NewMsgSend(from, atestutil.AccAddress(t), sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 1)))
+}
+
+func TestMsgPolicyFee(t *testing.T) {
+	decorator, newBlock := setupMsgPolicy(t)
+	ctx := newBlock()
+
+	from := atestutil.AccAddress(t)
+
+	tx := msgsTx{
+		feeTx: feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 1500))},
+		msgs:  []sdk.Msg{sendMsg(t, from), sendMsg(t, from)},
+	}
+
+	// two messages require 2000uakt
+	_, err := decorator.AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// policy fees are not valued in other denominations
+	tx.fee = sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUact, 100000))
+
+	_, err = decorator.AnteHandle(newBlock(), tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	tx.fee = sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 2000))
+
+	_, err = decorator.AnteHandle(newBlock(), tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestMsgPolicyQuota(t *testing.T) {
+	decorator, newBlock := setupMsgPolicy(t)
+	ctx := newBlock()
+
+	from := atestutil.AccAddress(t)
+	fee := feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 1000))}
+
+	for i := 0; i < 2; i++ {
+		_, err := decorator.AnteHandle(ctx, msgsTx{feeTx: fee, msgs: []sdk.Msg{sendMsg(t, from)}}, false, nextAnte)
+		require.NoError(t, err)
+	}
+
+	_, err := decorator.AnteHandle(ctx, msgsTx{feeTx: fee, msgs: []sdk.Msg{sendMsg(t, from)}}, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	// quota is tracked per account
+	_, err = decorator.AnteHandle(ctx, msgsTx{feeTx: fee, msgs: []sdk.Msg{sendMsg(t, atestutil.AccAddress(t))}}, false, nextAnte)
+	require.NoError(t, err)
+
+	// and reset every block
+	_, err = decorator.AnteHandle(newBlock(), msgsTx{feeTx: fee, msgs: []sdk.Msg{sendMsg(t, from)}}, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestMsgPolicySimulate(t *testing.T) {
+	decorator, newBlock := setupMsgPolicy(t)
+	ctx := newBlock()
+
+	from := atestutil.AccAddress(t)
+	tx := msgsTx{msgs: []sdk.Msg{sendMsg(t, from)}}
+
+	// fee is not checked while simulating, quota still is
+	for i := 0; i < 2; i++ {
+		_, err := decorator.AnteHandle(ctx, tx, true, nextAnte)
+		require.NoError(t, err)
+	}
+
+	_, err := decorator.AnteHandle(ctx, tx, true, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	_, err = decorator.AnteHandle(newBlock(), tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+}
+
+func TestMsgPolicyAuthzExec(t *testing.T) {
+	decorator, newBlock := setupMsgPolicy(t)
+	ctx := newBlock()
+
+	granter := atestutil.AccAddress(t)
+	grantee := atestutil.AccAddress(t)
+
+	exec := authz.NewMsgExec(grantee, []sdk.Msg{sendMsg(t, granter), sendMsg(t, granter)})
+	nested := authz.NewMsgExec(grantee, []sdk.Msg{&exec})
+
+	// fee is required for messages nested in MsgExec
+	_, err := decorator.AnteHandle(ctx, msgsTx{msgs: []sdk.Msg{&nested}}, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	fee := feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 2000))}
+
+	_, err = decorator.AnteHandle(newBlock(), msgsTx{feeTx: fee, msgs: []sdk.Msg{&nested}}, false, nextAnte)
+	require.NoError(t, err)
+
+	// and counted towards quota of the granter
+	ctx = newBlock()
+
+	_, err = decorator.AnteHandle(ctx, msgsTx{feeTx: fee, msgs: []sdk.Msg{&exec}}, false, nextAnte)
+	require.NoError(t, err)
+
+	_, err = decorator.AnteHandle(ctx, msgsTx{feeTx: fee, msgs: []sdk.Msg{sendMsg(t, granter)}}, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
@@ -1,14 +1,17 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cast"
 
@@ -61,7 +64,9 @@ import (
 	"pkg.akt.dev/go/sdkutil"
 
 	apptypes "pkg.akt.dev/node/v2/app/types"
+	"pkg.akt.dev/node/v2/indexer"
 	utypes "pkg.akt.dev/node/v2/upgrades/types"
+	"pkg.akt.dev/node/v2/util/metrics"
 	"pkg.akt.dev/node/v2/util/partialord"
 	"pkg.akt.dev/node/v2/x/bme"
 	"pkg.akt.dev/node/v2/x/escrow"
@@ -96,6 +101,7 @@ type AkashApp struct {
 	interfaceRegistry codectypes.InterfaceRegistry
 	sm                *module.SimulationManager
 	invCheckPeriod    uint
+	indexer           *indexer.Indexer
 }
 
 // NewApp creates and returns a new Akash App.
@@ -245,6 +251,10 @@ func NewApp(
 	app.MountKVStores(app.GetKVStoreKey())
 	app.MountTransientStores(app.GetTransientStoreKey())
 
+	if err := app.registerIndexer(appOpts); err != nil {
+		panic(fmt.Sprintf("error while registering indexer: %s", err))
+	}
+
 	anteOpts := HandlerOptions{
 		HandlerOptions: ante.HandlerOptions{
 			AccountKeeper:   app.Keepers.Cosmos.Acct,
@@ -253,8 +263,12 @@ func NewApp(
 			SignModeHandler: encodingConfig.TxConfig.SignModeHandler(),
 			SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
 		},
-		CDC:       app.cdc,
-		GovKeeper: app.Keepers.Cosmos.Gov,
+		CDC:          app.cdc,
+		GovKeeper:    app.Keepers.Cosmos.Gov,
+		MarketKeeper: app.Keepers.Akash.Market,
+		OracleKeeper: app.Keepers.Akash.Oracle,
+		BmeKeeper:    app.Keepers.Akash.Bme,
+		AnteTKey:     app.GetTransientStoreKey()[apptypes.AnteTStoreKey],
 	}
 
 	anteHandler, err := NewAnteHandler(anteOpts)
@@ -278,6 +292,15 @@ func NewApp(
 	app.SetPrecommiter(app.Precommitter)
 	app.SetPrepareCheckStater(app.PrepareCheckStater)
 
+	// module metrics are served by the node prometheus endpoint along with telemetry
+	if cast.ToBool(appOpts.Get("telemetry.enabled")) {
+		if err := metrics.Register(prometheus.DefaultRegisterer, appStateCollector); err != nil {
+			panic(fmt.Sprintf("error while registering metrics: %s", err))
+		}
+
+		appStateCollector.setApp(app)
+	}
+
 	if loadLatest {
 		if err := app.LoadLatestVersion(); err != nil {
 			cmos.Exit("app initialization:" + err.Error())
@@ -439,17 +462,32 @@ func (app *AkashApp) PreBlocker(ctx sdk.Context, _ *abci.RequestFinalizeBlock) (
 	// because Go is pass by value.
 	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
 
-	return app.MM.PreBlock(ctx)
-}
+	res, err := app.MM.PreBlock(ctx)
+	if err != nil {
+		return res, err
+	}
 
-// BeginBlocker is a function in which application updates every begin block
-func (app *AkashApp) BeginBlocker(ctx sdk.Context) (sdk.BeginBlock, error) {
+	// height patches are applied once upgrade scheduled for the same height (if any) has been run,
+	// so patch operates on state layout of the running binary
 	if patch, exists := utypes.GetHeightPatchesList()[ctx.BlockHeight()]; exists {
 		app.Logger().Info(fmt.Sprintf("found patch %s for current height %d. applying...", patch.Name(), ctx.BlockHeight()))
 		patch.Begin(ctx, &app.Keepers)
 		app.Logger().Info(fmt.Sprintf("patch %s applied successfully at height %d", patch.Name(), ctx.BlockHeight()))
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				utypes.EventTypeHeightPatch,
+				sdk.NewAttribute(utypes.AttributeKeyPatchName, patch.Name()),
+				sdk.NewAttribute(utypes.AttributeKeyPatchHeight, strconv.FormatInt(ctx.BlockHeight(), 10)),
+			),
+		)
 	}
 
+	return res, nil
+}
+
+// BeginBlocker is a function in which application updates every begin block
+func (app *AkashApp) BeginBlocker(ctx sdk.Context) (sdk.BeginBlock, error) {
 	return app.MM.BeginBlock(ctx)
 }
 
@@ -589,6 +627,42 @@ func RegisterSwaggerAPI(_ client.Context, rtr *mux.Router) {
 	rtr.PathPrefix("/swagger/").Handler(http.StripPrefix("/swagger/", staticServer))
 }
 
+// registerIndexer registers indexer as ABCI listener of marketplace events and escrow state changes,
+// if it is enabled in app.toml
+func (app *AkashApp) registerIndexer(appOpts servertypes.AppOptions) error {
+	cfg, err := indexer.ReadConfig(appOpts)
+	if err != nil || !cfg.Enable {
+		return err
+	}
+
+	app.indexer, err = indexer.New(cfg, app.cdc, app.Logger())
+	if err != nil {
+		return err
+	}
+
+	app.SetStreamingManager(storetypes.StreamingManager{
+		ABCIListeners: []storetypes.ABCIListener{app.indexer},
+		StopNodeOnErr: cfg.StopNodeOnErr,
+	})
+
+	app.CommitMultiStore().AddListeners([]storetypes.StoreKey{app.GetKVStoreKey()[escrow.StoreKey]})
+
+	return nil
+}
+
+// Close stops serving state metrics of the app, closes indexer, if enabled, and the underlying BaseApp
+func (app *AkashApp) Close() error {
+	var err error
+
+	appStateCollector.releaseApp(app)
+
+	if app.indexer != nil {
+		err = app.indexer.Close()
+	}
+
+	return errors.Join(err, app.BaseApp.Close())
+}
+
 // LoadHeight method of AkashApp loads baseapp application version with given height
 func (app *AkashApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
@@ -336,6 +336,7 @@ func Setup(opts ...SetupAppOption) *AkashApp {
 				Validators:    []abci.ValidatorUpdate{},
 				AppStateBytes: stateBytes,
 				ChainId:       cfg.chainID,
+				InitialHeight: cfg.initialHeight,
 			},
 		)
 		if err != nil {
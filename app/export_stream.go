@@ -0,0 +1,416 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	dv1 "pkg.akt.dev/go/node/deployment/v1"
+	dvbeta "pkg.akt.dev/go/node/deployment/v1beta4"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+
+	"pkg.akt.dev/node/v2/x/market"
+)
+
+// ExportFilter narrows down records exported by the module.
+// Empty fields match every record.
+type ExportFilter struct {
+	// Owner matches deployments, groups, orders, bids and leases of the owner
+	Owner string
+	// Provider matches bids and leases of the provider
+	Provider string
+	// State matches records in the given state, e.g. "active" or "closed".
+	// Lease migrations, uptimes and SLA records have no state and are matched by owner and provider only.
+	State string
+}
+
+// ExportFilters are export filters keyed by module name
+type ExportFilters map[string]ExportFilter
+
+// AppStateWriter writes exported application state to w
+type AppStateWriter func(w io.Writer) error
+
+// ParseExportFilters parses filters given in form of module.field=value,
+// for example deployment.owner=akash1... or market.state=active
+func ParseExportFilters(args []string) (ExportFilters, error) {
+	filters := make(ExportFilters)
+
+	for _, arg := range args {
+		field, value, found := strings.Cut(arg, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid export filter %q: expected module.field=value", arg)
+		}
+
+		module, field, found := strings.Cut(field, ".")
+		if !found {
+			return nil, fmt.Errorf("invalid export filter %q: expected module.field=value", arg)
+		}
+
+		if module != dv1.ModuleName && module != mv1.ModuleName {
+			return nil, fmt.Errorf("invalid export filter %q: module %q does not support filters", arg, module)
+		}
+
+		filter := filters[module]
+
+		switch field {
+		case "owner":
+			if _, err := sdk.AccAddressFromBech32(value); err != nil {
+				return nil, fmt.Errorf("invalid export filter %q: %w", arg, err)
+			}
+			filter.Owner = value
+		case "provider":
+			if module != mv1.ModuleName {
+				return nil, fmt.Errorf("invalid export filter %q: provider filter is supported by %s module only", arg, mv1.ModuleName)
+			}
+			if _, err := sdk.AccAddressFromBech32(value); err != nil {
+				return nil, fmt.Errorf("invalid export filter %q: %w", arg, err)
+			}
+			filter.Provider = value
+		case "state":
+			filter.State = value
+		default:
+			return nil, fmt.Errorf("invalid export filter %q: unknown field %q", arg, field)
+		}
+
+		filters[module] = filter
+	}
+
+	return filters, nil
+}
+
+// ExportAppStateAndValidatorsStream exports the state of the application same way as ExportAppStateAndValidators does,
+// except AppState of the returned ExportedApp is left empty. App state is written by the returned AppStateWriter
+// one module at a time instead, and records of deployment and market modules are written one by one,
+// so the whole state is never held in memory. Without filters written state is the compact form of AppState
+// exported by ExportAppStateAndValidators. Deployment and market records can be narrowed down by filters.
+func (app *AkashApp) ExportAppStateAndValidatorsStream(
+	forZeroHeight bool,
+	jailAllowedAddrs []string,
+	modulesToExport []string,
+	filters ExportFilters,
+) (servertypes.ExportedApp, AppStateWriter, error) {
+	ctx := app.NewContextLegacy(true, cmproto.Header{Height: app.LastBlockHeight()})
+
+	height := app.LastBlockHeight() + 1
+
+	if forZeroHeight {
+		height = 0
+		app.prepForZeroHeightGenesis(ctx, jailAllowedAddrs)
+	}
+
+	modules := modulesToExport
+	if len(modules) == 0 {
+		modules = app.MM.OrderExportGenesis
+	}
+
+	for module := range filters {
+		if !slices.Contains(modules, module) {
+			return servertypes.ExportedApp{}, nil, fmt.Errorf("filter is set for module %q which is not exported", module)
+		}
+	}
+
+	validators, err := staking.WriteValidators(ctx, app.Keepers.Cosmos.Staking)
+	if err != nil {
+		return servertypes.ExportedApp{}, nil, err
+	}
+
+	exported := servertypes.ExportedApp{
+		Validators:      validators,
+		Height:          height,
+		ConsensusParams: app.GetConsensusParams(ctx),
+	}
+
+	writer := func(w io.Writer) error {
+		sw := &stateWriter{w: w, cdc: app.cdc}
+
+		sw.write("{")
+
+		count := 0
+
+		// modules are written in order of their names, same as ExportAppStateAndValidators writes them
+		for _, module := range slices.Sorted(slices.Values(modules)) {
+			switch module {
+			case dv1.ModuleName:
+				sw.field(count, module)
+				app.streamDeploymentGenesis(ctx, sw, filters[module])
+			case mv1.ModuleName:
+				sw.field(count, module)
+				app.streamMarketGenesis(ctx, sw, filters[module])
+			default:
+				genState, err := app.MM.ExportGenesisForModules(ctx, app.cdc, []string{module})
+				if err != nil {
+					return err
+				}
+
+				// modules without genesis are not exported
+				state, found := genState[module]
+				if !found {
+					continue
+				}
+
+				sw.field(count, module)
+				sw.raw(state)
+			}
+
+			count++
+
+			if sw.err != nil {
+				return fmt.Errorf("failed to export %s module: %w", module, sw.err)
+			}
+		}
+
+		sw.write("}")
+
+		return sw.err
+	}
+
+	return exported, writer, nil
+}
+
+func (app *AkashApp) streamDeploymentGenesis(ctx sdk.Context, sw *stateWriter, filter ExportFilter) {
+	params, err := app.Keepers.Akash.Deployment.GetParams(ctx)
+	if err != nil {
+		sw.err = err
+		return
+	}
+
+	sw.spliced(&dvbeta.GenesisState{Params: params}, splicedField{
+		name: "deployments",
+		stream: func() {
+			count := 0
+
+			err := app.Keepers.Akash.Deployment.WithDeployments(ctx, func(deployment dv1.Deployment) bool {
+				if (filter.Owner != "" && deployment.ID.Owner != filter.Owner) ||
+					(filter.State != "" && deployment.State.String() != filter.State) {
+					return false
+				}
+
+				groups, err := app.Keepers.Akash.Deployment.GetGroups(ctx, deployment.ID)
+				if err != nil {
+					sw.err = err
+					return true
+				}
+
+				sw.element(count, &dvbeta.GenesisDeployment{
+					Deployment: deployment,
+					Groups:     groups,
+				})
+				count++
+
+				return sw.err != nil
+			})
+			if err != nil && sw.err == nil {
+				sw.err = err
+			}
+		},
+	})
+}
+
+func (app *AkashApp) streamMarketGenesis(ctx sdk.Context, sw *stateWriter, filter ExportFilter) {
+	match := func(owner, provider, state string) bool {
+		return (filter.Owner == "" || owner == filter.Owner) &&
+			(filter.Provider == "" || provider == filter.Provider) &&
+			(filter.State == "" || state == filter.State)
+	}
+
+	// lease migrations, uptimes and SLA records are few and exported along with params
+	state := market.ExportGenesisSkeleton(ctx, app.Keepers.Akash.Market)
+
+	skip := func(id mv1.LeaseID) bool {
+		return (filter.Owner != "" && id.Owner != filter.Owner) ||
+			(filter.Provider != "" && id.Provider != filter.Provider)
+	}
+
+	if filter.Owner != "" || filter.Provider != "" {
+		state.LeaseMigrations = slices.DeleteFunc(state.LeaseMigrations, func(r mv1.LeaseMigration) bool { return skip(r.ID) })
+		state.LeaseMigrationCloses = slices.DeleteFunc(state.LeaseMigrationCloses, func(r mv1.LeaseMigrationClose) bool { return skip(r.ID) })
+		state.LeaseUptimes = slices.DeleteFunc(state.LeaseUptimes, func(r mv1.LeaseUptime) bool { return skip(r.ID) })
+		state.LeaseSLASettlements = slices.DeleteFunc(state.LeaseSLASettlements, func(r mv1.LeaseSLASettlement) bool { return skip(r.ID) })
+		state.LeaseSLARecords = slices.DeleteFunc(state.LeaseSLARecords, func(r mv1.LeaseSLARecord) bool { return skip(r.ID) })
+	}
+
+	sw.spliced(state,
+		splicedField{
+			name: "bids",
+			stream: func() {
+				count := 0
+				app.Keepers.Akash.Market.WithBids(ctx, func(bid mvbeta.Bid) bool {
+					if !match(bid.ID.Owner, bid.ID.Provider, bid.State.String()) {
+						return false
+					}
+
+					sw.element(count, &bid)
+					count++
+
+					return sw.err != nil
+				})
+			},
+		},
+		splicedField{
+			name: "leases",
+			stream: func() {
+				count := 0
+				app.Keepers.Akash.Market.WithLeases(ctx, func(lease mv1.Lease) bool {
+					if !match(lease.ID.Owner, lease.ID.Provider, lease.State.String()) {
+						return false
+					}
+
+					sw.element(count, &lease)
+					count++
+
+					return sw.err != nil
+				})
+			},
+		},
+		splicedField{
+			name: "orders",
+			stream: func() {
+				count := 0
+				app.Keepers.Akash.Market.WithOrders(ctx, func(order mvbeta.Order) bool {
+					// orders have no provider, so they are omitted when exporting state of a single provider
+					if filter.Provider != "" || !match(order.ID.Owner, "", order.State.String()) {
+						return false
+					}
+
+					sw.element(count, &order)
+					count++
+
+					return sw.err != nil
+				})
+			},
+		},
+	)
+}
+
+// stateWriter writes JSON to the underlying writer and keeps the first error,
+// so sequence of writes can be checked once.
+type stateWriter struct {
+	w   io.Writer
+	cdc codec.JSONCodec
+	err error
+}
+
+func (sw *stateWriter) write(s string) {
+	if sw.err != nil {
+		return
+	}
+
+	_, sw.err = io.WriteString(sw.w, s)
+}
+
+func (sw *stateWriter) key(name string) {
+	bz, _ := json.Marshal(name)
+	sw.write(string(bz) + ":")
+}
+
+func (sw *stateWriter) field(idx int, name string) {
+	if idx > 0 {
+		sw.write(",")
+	}
+
+	sw.key(name)
+}
+
+// raw writes JSON compacted the same way json.Marshal writes json.RawMessage
+func (sw *stateWriter) raw(bz []byte) {
+	if sw.err != nil {
+		return
+	}
+
+	bz, sw.err = json.Marshal(json.RawMessage(bz))
+
+	sw.write(string(bz))
+}
+
+func (sw *stateWriter) marshal(msg proto.Message) []byte {
+	if sw.err != nil {
+		return nil
+	}
+
+	bz, err := sw.cdc.MarshalJSON(msg)
+	if err != nil {
+		sw.err = err
+		return nil
+	}
+
+	bz, sw.err = json.Marshal(json.RawMessage(bz))
+
+	return bz
+}
+
+func (sw *stateWriter) message(msg proto.Message) {
+	if bz := sw.marshal(msg); sw.err == nil {
+		sw.write(string(bz))
+	}
+}
+
+func (sw *stateWriter) element(idx int, msg proto.Message) {
+	if idx > 0 {
+		sw.write(",")
+	}
+
+	sw.message(msg)
+}
+
+// splicedField is repeated field of exported state which elements are streamed one by one
+type splicedField struct {
+	name   string
+	stream func()
+}
+
+// spliced writes state with elements of the given repeated fields, left empty in the state,
+// streamed in their place. Other fields are written as the codec marshals them.
+func (sw *stateWriter) spliced(state proto.Message, fields ...splicedField) {
+	bz := sw.marshal(state)
+	if sw.err != nil {
+		return
+	}
+
+	type splice struct {
+		at    int
+		field splicedField
+	}
+
+	splices := make([]splice, 0, len(fields))
+
+	for _, field := range fields {
+		name, _ := json.Marshal(field.name)
+		empty := append(name, []byte(":[]")...)
+
+		at := bytes.Index(bz, empty)
+		if at < 0 {
+			sw.err = fmt.Errorf("field %q is not found in exported state", field.name)
+			return
+		}
+
+		// elements go in between the brackets
+		splices = append(splices, splice{at: at + len(empty) - 1, field: field})
+	}
+
+	slices.SortFunc(splices, func(a, b splice) int {
+		return a.at - b.at
+	})
+
+	from := 0
+
+	for _, s := range splices {
+		sw.write(string(bz[from:s.at]))
+		s.field.stream()
+
+		from = s.at
+	}
+
+	sw.write(string(bz[from:]))
+}
@@ -0,0 +1,94 @@
+package app_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/app"
+	"pkg.akt.dev/node/v2/x/market"
+)
+
+func TestParseExportFilters(t *testing.T) {
+	filters, err := app.ParseExportFilters([]string{"market.state=active", "deployment.state=closed"})
+	require.NoError(t, err)
+	require.Equal(t, app.ExportFilters{
+		"market":     {State: "active"},
+		"deployment": {State: "closed"},
+	}, filters)
+
+	for _, arg := range []string{
+		"market.state",
+		"market.state=",
+		"state=active",
+		"bank.state=active",
+		"market.height=1",
+		"deployment.provider=akash1",
+		"market.owner=invalid",
+	} {
+		_, err = app.ParseExportFilters([]string{arg})
+		require.Error(t, err, arg)
+	}
+}
+
+func TestExportAppStateStreamMatchesExport(t *testing.T) {
+	akash := app.Setup(app.WithHome(t.TempDir()), app.WithGenesis(app.GenesisStateWithValSet))
+
+	// export reads check state, so records set here are exported by both
+	ctx := akash.NewContextLegacy(true, cmproto.Header{Height: akash.LastBlockHeight()})
+
+	params, err := akash.Keepers.Akash.Market.GetParams(ctx)
+	require.NoError(t, err)
+
+	lid := testutil.LeaseID(t)
+
+	market.InitGenesis(ctx, akash.Keepers.Akash.Market, &mvbeta.GenesisState{
+		Params: params,
+		LeaseMigrations: []mv1.LeaseMigration{
+			{
+				ID:      lid,
+				OrderID: mv1.MakeOrderID(lid.GroupID(), lid.OSeq+1),
+				Overlap: time.Hour,
+			},
+		},
+		LeaseMigrationCloses: []mv1.LeaseMigrationClose{
+			{
+				ID:       testutil.LeaseID(t),
+				Deadline: 1000,
+			},
+		},
+		LeaseUptimes: []mv1.LeaseUptime{
+			{
+				ID:     lid,
+				Epoch:  1,
+				Signer: lid.Owner,
+				Uptime: 9500,
+				Height: 10,
+			},
+		},
+	})
+
+	exported, err := akash.ExportAppStateAndValidators(false, nil, nil)
+	require.NoError(t, err)
+
+	streamed, write, err := akash.ExportAppStateAndValidatorsStream(false, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, exported.Height, streamed.Height)
+	require.Equal(t, exported.Validators, streamed.Validators)
+
+	var buf bytes.Buffer
+	require.NoError(t, write(&buf))
+
+	var state bytes.Buffer
+	require.NoError(t, json.Indent(&state, buf.Bytes(), "", "  "))
+	require.Equal(t, string(exported.AppState), state.String())
+}
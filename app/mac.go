@@ -8,6 +8,7 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	ibctransfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
 	emodule "pkg.akt.dev/go/node/escrow/module"
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
 
 	bmemodule "pkg.akt.dev/node/v2/x/bme"
 )
@@ -17,6 +18,7 @@ func ModuleAccountPerms() map[string][]string {
 		authtypes.FeeCollectorName:     nil,
 		bmemodule.ModuleName:           {authtypes.Burner, authtypes.Minter},
 		emodule.ModuleName:             nil,
+		ptypes.ModuleName:              nil,
 		distrtypes.ModuleName:          nil,
 		minttypes.ModuleName:           {authtypes.Minter},
 		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
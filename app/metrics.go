@@ -0,0 +1,238 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	etypes "pkg.akt.dev/go/node/escrow/types/v1"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	otypes "pkg.akt.dev/go/node/oracle/v2"
+
+	"pkg.akt.dev/node/v2/util/metrics"
+)
+
+func stateDesc(subsystem, name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, subsystem, name), help, labels, nil)
+}
+
+var (
+	descOrders        = stateDesc("market", "orders", "Number of orders by state", "state")
+	descBids          = stateDesc("market", "bids", "Number of bids by state", "state")
+	descLeases        = stateDesc("market", "leases", "Number of leases by state", "state")
+	descEscrowAccts   = stateDesc("escrow", "accounts", "Number of escrow accounts by state", "state")
+	descEscrowBalance = stateDesc("escrow", "balance", "Sum of escrow account funds by state and denom, negative for overdrawn accounts", "state", "denom")
+	descOraclePrice   = stateDesc("oracle", "price", "Aggregated TWAP price", "denom", "base_denom")
+	descOracleHealthy = stateDesc("oracle", "price_healthy", "1 if aggregated price is healthy, 0 otherwise", "denom", "base_denom")
+	descOracleSources = stateDesc("oracle", "price_sources", "Number of sources of the price, total and healthy", "denom", "base_denom", "kind")
+	descOracleAuth    = stateDesc("oracle", "authorized_sources", "Number of authorized price sources")
+	descBmeCR         = stateDesc("bme", "collateral_ratio", "Collateral ratio of BME vault")
+	descBmeStatus     = stateDesc("bme", "mint_status", "1 for the current mint status, 0 for others", "status")
+	descBmePending    = stateDesc("bme", "pending_records", "Number of ledger records pending execution")
+)
+
+// stateCollector exports module state of the last committed block as prometheus gauges.
+// Metrics are computed on the first scrape of a height and served from cache to the following ones,
+// closed orders, bids, leases and escrow accounts are not counted as they only grow.
+type stateCollector struct {
+	lock    sync.Mutex
+	app     *AkashApp
+	height  int64
+	metrics []prometheus.Metric
+}
+
+var _ prometheus.Collector = (*stateCollector)(nil)
+
+// appStateCollector is registered once per process and serves state of the app set last,
+// so an app created after another one in the same process does not leave stale state served.
+var appStateCollector = &stateCollector{}
+
+// setApp makes collector serve state of the app
+func (c *stateCollector) setApp(app *AkashApp) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.app = app
+	c.height = 0
+	c.metrics = nil
+}
+
+// releaseApp stops collector serving state of the app, if it still does
+func (c *stateCollector) releaseApp(app *AkashApp) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.app != app {
+		return
+	}
+
+	c.app = nil
+	c.height = 0
+	c.metrics = nil
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		descOrders, descBids, descLeases,
+		descEscrowAccts, descEscrowBalance,
+		descOraclePrice, descOracleHealthy, descOracleSources, descOracleAuth,
+		descBmeCR, descBmeStatus, descBmePending,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.snapshot() {
+		ch <- m
+	}
+}
+
+func (c *stateCollector) snapshot() []prometheus.Metric {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.app == nil {
+		return nil
+	}
+
+	height := c.app.LastBlockHeight()
+	if height == 0 || height == c.height {
+		return c.metrics
+	}
+
+	ctx, err := c.app.CreateQueryContext(height, false)
+	if err != nil {
+		c.app.Logger().Error("failed to create context for state metrics", "height", height, "err", err)
+		return c.metrics
+	}
+
+	res, err := c.collect(ctx)
+	if err != nil {
+		c.app.Logger().Error("failed to collect state metrics", "height", height, "err", err)
+		return c.metrics
+	}
+
+	c.height = height
+	c.metrics = res
+
+	return res
+}
+
+func (c *stateCollector) collect(ctx sdk.Context) (res []prometheus.Metric, err error) {
+	// keepers panic on store errors, which must not take the node down from the metrics endpoint
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	gauge := func(desc *prometheus.Desc, val float64, labels ...string) {
+		res = append(res, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, val, labels...))
+	}
+
+	keepers := c.app.Keepers.Akash
+
+	// market
+	for _, state := range []mvbeta.Order_State{mvbeta.OrderOpen, mvbeta.OrderActive} {
+		gauge(descOrders, float64(keepers.Market.OrderCountForState(ctx, state)), state.String())
+	}
+
+	for _, state := range []mvbeta.Bid_State{mvbeta.BidOpen, mvbeta.BidActive} {
+		gauge(descBids, float64(keepers.Market.BidCountForState(ctx, state)), state.String())
+	}
+
+	for _, state := range []mv1.Lease_State{mv1.LeaseActive, mv1.LeaseInsufficientFunds, mv1.LeaseReclaiming} {
+		gauge(descLeases, float64(keepers.Market.LeaseCountForState(ctx, state)), state.String())
+	}
+
+	// escrow balances are not tracked by the keeper, open and overdrawn accounts are scanned instead.
+	// The scan is linear in number of such accounts and runs at most once per height, on scrape.
+	for _, state := range []etypes.State{etypes.StateOpen, etypes.StateOverdrawn} {
+		count := 0
+		balances := make(map[string]float64)
+
+		keepers.Escrow.WithAccountsForState(ctx, state, func(acc etypes.Account) bool {
+			count++
+
+			for _, funds := range acc.State.Funds {
+				amount, _ := funds.Amount.Float64()
+				balances[funds.Denom] += amount
+			}
+
+			return false
+		})
+
+		gauge(descEscrowAccts, float64(count), state.String())
+
+		for denom, amount := range balances {
+			gauge(descEscrowBalance, amount, state.String(), denom)
+		}
+	}
+
+	// oracle
+	oparams, err := keepers.Oracle.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gauge(descOracleAuth, float64(len(oparams.Sources)))
+
+	err = keepers.Oracle.WithAggregatedPrices(ctx, func(id otypes.DataID, price otypes.AggregatedPrice, health otypes.PriceHealth) bool {
+		twap, _ := price.TWAP.Float64()
+
+		healthy := 0.0
+		if health.IsHealthy {
+			healthy = 1
+		}
+
+		gauge(descOraclePrice, twap, id.Denom, id.BaseDenom)
+		gauge(descOracleHealthy, healthy, id.Denom, id.BaseDenom)
+		gauge(descOracleSources, float64(health.TotalSources), id.Denom, id.BaseDenom, "total")
+		gauge(descOracleSources, float64(health.TotalHealthySources), id.Denom, id.BaseDenom, "healthy")
+
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// bme
+	if cr, err := keepers.Bme.GetCollateralRatio(ctx); err == nil {
+		val, _ := cr.Float64()
+		gauge(descBmeCR, val)
+	}
+
+	status, err := keepers.Bme.GetMintStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for val := range bmetypes.MintStatus_name {
+		current := 0.0
+		if bmetypes.MintStatus(val) == status {
+			current = 1
+		}
+
+		gauge(descBmeStatus, current, bmetypes.MintStatus(val).String())
+	}
+
+	pending := 0
+
+	err = keepers.Bme.IterateLedgerPendingRecords(ctx, func(bmetypes.LedgerRecordID, bmetypes.LedgerPendingRecord) (bool, error) {
+		pending++
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gauge(descBmePending, float64(pending))
+
+	return res, nil
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCollectorApp(t *testing.T) {
+	c := &stateCollector{}
+
+	first := &AkashApp{}
+	second := &AkashApp{}
+
+	c.setApp(first)
+	c.setApp(second)
+
+	// closing app which is not served anymore leaves the collector as is
+	c.releaseApp(first)
+	require.Same(t, second, c.app)
+
+	c.releaseApp(second)
+	require.Nil(t, c.app)
+	require.Empty(t, c.snapshot())
+}
@@ -9,11 +9,12 @@ import (
 type SetupGenesisFn func(cdc codec.Codec) GenesisState
 
 type setupAppOptions struct {
-	encCfg    sdkutil.EncodingConfig
-	home      string
-	chainID   string
-	checkTx   bool
-	genesisFn SetupGenesisFn
+	encCfg        sdkutil.EncodingConfig
+	home          string
+	chainID       string
+	checkTx       bool
+	genesisFn     SetupGenesisFn
+	initialHeight int64
 }
 
 type SetupAppOption func(*setupAppOptions)
@@ -50,3 +51,10 @@ func WithEncConfig(val sdkutil.EncodingConfig) SetupAppOption {
 		t.encCfg = val
 	}
 }
+
+// WithInitialHeight sets height of the first block after genesis
+func WithInitialHeight(val int64) SetupAppOption {
+	return func(t *setupAppOptions) {
+		t.initialHeight = val
+	}
+}
@@ -64,10 +64,15 @@ type TestnetAccount struct {
 }
 
 type TestnetConfig struct {
-	Accounts   []TestnetAccount
-	Validators []TestnetValidator
-	Gov        TestnetGovConfig
-	Upgrade    TestnetUpgrade
+	Accounts    []TestnetAccount
+	Validators  []TestnetValidator
+	Gov         TestnetGovConfig
+	Upgrade     TestnetUpgrade
+	Oracle      TestnetOracle
+	BME         TestnetBME
+	Marketplace TestnetMarketplace
+	// BlockTime is time of the last block of the forked state
+	BlockTime time.Time
 }
 
 func TrimQuotes(data string) string {
@@ -294,6 +299,27 @@ func InitAkashAppForTestnet(
 		panic(err.Error())
 	}
 
+	// MARKETPLACE
+	//
+
+	// marketplace records are created at the last block of the forked state
+	mctx := ctx.WithBlockHeight(app.LastBlockHeight()).WithBlockTime(tcfg.BlockTime)
+
+	err = app.initTestnetBME(mctx, tcfg.BME)
+	if err != nil {
+		panic(err)
+	}
+
+	err = app.initTestnetOracle(mctx, tcfg.Oracle)
+	if err != nil {
+		panic(err)
+	}
+
+	err = app.initTestnetMarketplace(mctx, tcfg.Marketplace)
+	if err != nil {
+		panic(err)
+	}
+
 	// UPGRADE
 	//
 	if tcfg.Upgrade.Name != "" {
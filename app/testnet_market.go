@@ -0,0 +1,219 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/cosmos/gogoproto/proto"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+
+	atypes "pkg.akt.dev/go/node/audit/v1"
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	dvbeta "pkg.akt.dev/go/node/deployment/v1beta4"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	otypes "pkg.akt.dev/go/node/oracle/v2"
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+)
+
+// TestnetAuditor is auditor registered in the forked state, declared in proto JSON form of Auditor
+type TestnetAuditor struct {
+	atypes.Auditor
+}
+
+// TestnetProvider is provider created in the forked state, declared in proto JSON form of MsgCreateProvider
+type TestnetProvider struct {
+	ptypes.MsgCreateProvider
+}
+
+// TestnetAuditedAttributes are provider attributes signed by auditor in the forked state,
+// declared in proto JSON form of MsgSignProviderAttributes
+type TestnetAuditedAttributes struct {
+	atypes.MsgSignProviderAttributes
+}
+
+// TestnetDeployment is deployment created in the forked state, declared in proto JSON form of MsgCreateDeployment
+type TestnetDeployment struct {
+	dvbeta.MsgCreateDeployment
+}
+
+// TestnetBid is bid placed in the forked state, declared in proto JSON form of MsgCreateBid
+type TestnetBid struct {
+	mvbeta.MsgCreateBid
+}
+
+// TestnetLease is lease created in the forked state, declared in proto JSON form of MsgCreateLease
+type TestnetLease struct {
+	mvbeta.MsgCreateLease
+}
+
+// TestnetMarketplace declares marketplace scenario injected into the forked state.
+// Records are created by the module message handlers in order auditors, providers, audited attributes,
+// deployments, bids and leases, so every record has to be valid against the state created before it,
+// e.g. audited attributes have to be signed by declared auditor with scopes covering them.
+// Owners of deployments and providers must be funded via accounts of the testnet config.
+type TestnetMarketplace struct {
+	Auditors          []TestnetAuditor           `json:"auditors"`
+	Providers         []TestnetProvider          `json:"providers"`
+	AuditedAttributes []TestnetAuditedAttributes `json:"audited_attributes"`
+	Deployments       []TestnetDeployment        `json:"deployments"`
+	Bids              []TestnetBid               `json:"bids"`
+	Leases            []TestnetLease             `json:"leases"`
+}
+
+type TestnetOraclePrice struct {
+	Denom     string            `json:"denom"`
+	BaseDenom string            `json:"base_denom"`
+	Price     sdkmath.LegacyDec `json:"price"`
+}
+
+// TestnetOracle declares price sources authorized in the forked state.
+// Every source submits each of the prices at the last block time.
+type TestnetOracle struct {
+	Sources []sdk.AccAddress     `json:"sources"`
+	Prices  []TestnetOraclePrice `json:"prices"`
+}
+
+// TestnetBME declares coins minted into BME vault of the forked state
+type TestnetBME struct {
+	VaultBalances sdk.Coins `json:"vault_balances"`
+}
+
+func unmarshalTestnetMsg(data []byte, msg proto.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(data), msg)
+}
+
+func (t *TestnetAuditor) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.Auditor)
+}
+
+func (t *TestnetProvider) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.MsgCreateProvider)
+}
+
+func (t *TestnetAuditedAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.MsgSignProviderAttributes)
+}
+
+func (t *TestnetDeployment) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.MsgCreateDeployment)
+}
+
+func (t *TestnetBid) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.MsgCreateBid)
+}
+
+func (t *TestnetLease) UnmarshalJSON(data []byte) error {
+	return unmarshalTestnetMsg(data, &t.MsgCreateLease)
+}
+
+// initTestnetBME mints configured balances into BME vault
+func (app *AkashApp) initTestnetBME(ctx sdk.Context, cfg TestnetBME) error {
+	if cfg.VaultBalances.IsZero() {
+		return nil
+	}
+
+	if err := app.Keepers.Cosmos.Bank.MintCoins(ctx, minttypes.ModuleName, cfg.VaultBalances); err != nil {
+		return err
+	}
+
+	return app.Keepers.Cosmos.Bank.SendCoinsFromModuleToModule(ctx, minttypes.ModuleName, bmetypes.ModuleName, cfg.VaultBalances)
+}
+
+// initTestnetOracle authorizes configured price sources and submits their prices
+func (app *AkashApp) initTestnetOracle(ctx sdk.Context, cfg TestnetOracle) error {
+	if len(cfg.Sources) == 0 {
+		return nil
+	}
+
+	params, err := app.Keepers.Akash.Oracle.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range cfg.Sources {
+		if !slices.Contains(params.Sources, source.String()) {
+			params.Sources = append(params.Sources, source.String())
+		}
+	}
+
+	if err = app.Keepers.Akash.Oracle.SetParams(ctx, params); err != nil {
+		return err
+	}
+
+	for _, price := range cfg.Prices {
+		id := otypes.DataID{
+			Denom:     price.Denom,
+			BaseDenom: price.BaseDenom,
+		}
+
+		for _, source := range cfg.Sources {
+			if err = app.Keepers.Akash.Oracle.AddPriceEntry(ctx, source, id, price.Price, ctx.BlockTime()); err != nil {
+				return fmt.Errorf("oracle price %s/%s from %s: %w", price.Denom, price.BaseDenom, source, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// initTestnetMarketplace delivers declared marketplace records to the message handlers
+func (app *AkashApp) initTestnetMarketplace(ctx sdk.Context, cfg TestnetMarketplace) error {
+	msgs := make([]sdk.Msg, 0, len(cfg.Auditors)+len(cfg.Providers)+len(cfg.AuditedAttributes)+len(cfg.Deployments)+len(cfg.Bids)+len(cfg.Leases))
+
+	for i := range cfg.Auditors {
+		msgs = append(msgs, &atypes.MsgRegisterAuditor{
+			Authority: app.Keepers.Akash.Audit.GetAuthority(),
+			Auditor:   cfg.Auditors[i].Auditor,
+		})
+	}
+
+	for i := range cfg.Providers {
+		msgs = append(msgs, &cfg.Providers[i].MsgCreateProvider)
+	}
+
+	for i := range cfg.AuditedAttributes {
+		msgs = append(msgs, &cfg.AuditedAttributes[i].MsgSignProviderAttributes)
+	}
+
+	for i := range cfg.Deployments {
+		msgs = append(msgs, &cfg.Deployments[i].MsgCreateDeployment)
+	}
+
+	for i := range cfg.Bids {
+		msgs = append(msgs, &cfg.Bids[i].MsgCreateBid)
+	}
+
+	for i := range cfg.Leases {
+		msgs = append(msgs, &cfg.Leases[i].MsgCreateLease)
+	}
+
+	for _, msg := range msgs {
+		if err := app.deliverTestnetMsg(ctx, msg); err != nil {
+			return fmt.Errorf("%s: %w", sdk.MsgTypeURL(msg), err)
+		}
+	}
+
+	return nil
+}
+
+func (app *AkashApp) deliverTestnetMsg(ctx sdk.Context, msg sdk.Msg) error {
+	if m, ok := msg.(sdk.HasValidateBasic); ok {
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+
+	handler := app.MsgServiceRouter().Handler(msg)
+	if handler == nil {
+		return fmt.Errorf("no message handler")
+	}
+
+	_, err := handler(ctx, msg)
+
+	return err
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	atypes "pkg.akt.dev/go/node/audit/v1"
+	"pkg.akt.dev/go/testutil"
+)
+
+func TestInitTestnetMarketplaceAuditedAttributes(t *testing.T) {
+	app := Setup(WithHome(t.TempDir()), WithGenesis(GenesisStateWithValSet))
+
+	owner := testutil.AccAddress(t)
+	auditor := testutil.AccAddress(t)
+
+	data := fmt.Sprintf(`{
+		"auditors": [
+			{"address": %q, "name": "auditor", "scopes": ["region"], "state": %q}
+		],
+		"audited_attributes": [
+			{"owner": %q, "auditor": %q, "attributes": [{"key": "region", "value": "us-west"}]}
+		]
+	}`, auditor, atypes.AuditorActive, owner, auditor)
+
+	var cfg TestnetMarketplace
+	require.NoError(t, json.Unmarshal([]byte(data), &cfg))
+	require.Len(t, cfg.Auditors, 1)
+
+	// audited attributes are rejected unless auditors are registered first
+	ctx := app.NewContext(false)
+
+	err := app.initTestnetMarketplace(ctx, TestnetMarketplace{AuditedAttributes: cfg.AuditedAttributes})
+	require.ErrorIs(t, err, atypes.ErrAuditorNotRegistered)
+
+	ctx, _ = app.NewContext(false).CacheContext()
+
+	err = app.initTestnetMarketplace(ctx, cfg)
+	require.NoError(t, err)
+
+	registered, found := app.Keepers.Akash.Audit.GetAuditor(ctx, auditor)
+	require.True(t, found)
+	require.Equal(t, atypes.AuditorActive, registered.State)
+
+	provider, found := app.Keepers.Akash.Audit.GetProviderAttributes(ctx, owner)
+	require.True(t, found)
+	require.Len(t, provider, 1)
+	require.Equal(t, auditor.String(), provider[0].Auditor)
+}
@@ -92,6 +92,9 @@ import (
 
 const (
 	AccountAddressPrefix = "akash"
+
+	// AnteTStoreKey is the transient store key of per block state kept by akash ante decorators
+	AnteTStoreKey = "transient_ante"
 )
 
 var ErrEmptyFieldName = errors.New("empty field name")
@@ -467,16 +470,20 @@ func (app *App) InitNormalKeepers(
 	app.Keepers.Akash.Provider = pkeeper.NewKeeper(
 		cdc,
 		app.keys[ptypes.StoreKey],
+		app.Keepers.Cosmos.Bank,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
 	app.Keepers.Akash.Audit = akeeper.NewKeeper(
 		cdc,
 		app.keys[atypes.StoreKey],
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
 	app.Keepers.Akash.Cert = ckeeper.NewKeeper(
 		cdc,
 		app.keys[ctypes.StoreKey],
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
 	app.Keepers.Akash.Epochs = epochskeeper.NewKeeper(
@@ -575,6 +582,8 @@ func (app *App) SetupHooks() {
 
 	app.Keepers.Akash.Epochs.SetHooks(epochstypes.NewMultiEpochHooks(
 		okeeper.EpochHooksFor(app.Keepers.Akash.Oracle),
+		ckeeper.EpochHooksFor(app.Keepers.Akash.Cert),
+		akeeper.EpochHooksFor(app.Keepers.Akash.Audit),
 	))
 }
 
@@ -641,6 +650,7 @@ func transientStoreKeys() []string {
 		paramstypes.TStoreKey,
 		bmetypes.TStoreKey,
 		otypes.TStoreKey,
+		AnteTStoreKey,
 	}
 }
 
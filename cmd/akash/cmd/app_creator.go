@@ -124,15 +124,55 @@ func (a appCreator) appExport(
 	appOpts servertypes.AppOptions,
 	modulesToExport []string,
 ) (servertypes.ExportedApp, error) {
+	akashApp, err := a.exportApp(logger, db, tio, height, appOpts)
+	if err != nil {
+		return servertypes.ExportedApp{}, err
+	}
+
+	return akashApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs, modulesToExport)
+}
+
+func (a appCreator) appExportStream(
+	logger log.Logger,
+	db dbm.DB,
+	tio io.Writer,
+	height int64,
+	forZeroHeight bool,
+	jailAllowedAddrs []string,
+	appOpts servertypes.AppOptions,
+	modulesToExport []string,
+	filters []string,
+) (servertypes.ExportedApp, func(io.Writer) error, error) {
+	exportFilters, err := akash.ParseExportFilters(filters)
+	if err != nil {
+		return servertypes.ExportedApp{}, nil, err
+	}
+
+	akashApp, err := a.exportApp(logger, db, tio, height, appOpts)
+	if err != nil {
+		return servertypes.ExportedApp{}, nil, err
+	}
+
+	return akashApp.ExportAppStateAndValidatorsStream(forZeroHeight, jailAllowedAddrs, modulesToExport, exportFilters)
+}
+
+// exportApp creates app loaded at the given height, -1 loads the latest height
+func (a appCreator) exportApp(
+	logger log.Logger,
+	db dbm.DB,
+	tio io.Writer,
+	height int64,
+	appOpts servertypes.AppOptions,
+) (*akash.AkashApp, error) {
 	var akashApp *akash.AkashApp
 
 	homePath, ok := appOpts.Get(cflags.FlagHome).(string)
 	if !ok || homePath == "" {
-		return servertypes.ExportedApp{}, errors.New("application home is not set")
+		return nil, errors.New("application home is not set")
 	}
 	viperAppOpts, ok := appOpts.(*viper.Viper)
 	if !ok {
-		return servertypes.ExportedApp{}, errors.New("appOpts is not viper.Viper")
+		return nil, errors.New("appOpts is not viper.Viper")
 	}
 	// overwrite the FlagInvCheckPeriod
 	viperAppOpts.Set(cflags.FlagInvCheckPeriod, 1)
@@ -142,13 +182,13 @@ func (a appCreator) appExport(
 		akashApp = akash.NewApp(logger, db, tio, false, uint(1), map[int64]bool{}, a.encCfg, appOpts)
 
 		if err := akashApp.LoadHeight(height); err != nil {
-			return servertypes.ExportedApp{}, err
+			return nil, err
 		}
 	} else {
 		akashApp = akash.NewApp(logger, db, tio, true, uint(1), map[int64]bool{}, a.encCfg, appOpts)
 	}
 
-	return akashApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs, modulesToExport)
+	return akashApp, nil
 }
 
 // newTestnetApp starts by running the normal newApp method. From there, the app interface returned is modified in order
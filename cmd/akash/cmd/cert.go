@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+
+	ctypes "pkg.akt.dev/go/node/cert/v1"
+)
+
+const (
+	FlagCRLSinceHeight = "since-height"
+	FlagCRLFormat      = "format"
+	FlagCRLFile        = "file"
+	FlagCRLIssuerCert  = "issuer-cert"
+	FlagCRLIssuerKey   = "issuer-key"
+	FlagCRLNextUpdate  = "next-update"
+
+	crlFormatX509 = "crl"
+	crlFormatJSON = "json"
+)
+
+var (
+	ErrCRLInvalidFormat = errors.New("invalid revocation list format")
+	ErrCRLIssuer        = errors.New("invalid revocation list issuer")
+)
+
+type certRevocation struct {
+	Owner     string    `json:"owner"`
+	Serial    string    `json:"serial"`
+	Height    int64     `json:"height"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+type certRevocationDigest struct {
+	SinceHeight int64            `json:"since_height"`
+	Height      int64            `json:"height"`
+	Revocations []certRevocation `json:"revocations"`
+}
+
+func CertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cert",
+		Short: "Certificate utilities",
+	}
+
+	cmd.AddCommand(certRevocationListCmd())
+
+	return cmd
+}
+
+func certRevocationListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revocation-list",
+		Short: "Export certificates revoked since given height",
+		Long: `Export certificates revoked since given height either as X.509 CRL signed
+by the provided issuer, or as JSON digest. Digest height is the chain height revocations
+were queried at, next incremental export may start from height next to it.
+Example:
+	akash cert revocation-list --since-height 100 --format json
+	akash cert revocation-list --format crl --issuer-cert ca.pem --issuer-key ca-key.pem --file revoked.crl
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			since, err := cmd.Flags().GetInt64(FlagCRLSinceHeight)
+			if err != nil {
+				return err
+			}
+
+			format, err := cmd.Flags().GetString(FlagCRLFormat)
+			if err != nil {
+				return err
+			}
+
+			if format != crlFormatX509 && format != crlFormatJSON {
+				return fmt.Errorf("%w: %s", ErrCRLInvalidFormat, format)
+			}
+
+			digest, err := queryCertRevocations(cmd, cctx, since)
+			if err != nil {
+				return err
+			}
+
+			var data []byte
+
+			if format == crlFormatJSON {
+				data, err = json.MarshalIndent(digest, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				data = append(data, '\n')
+			} else {
+				data, err = certRevocationsToCRL(cmd, digest)
+				if err != nil {
+					return err
+				}
+			}
+
+			file, err := cmd.Flags().GetString(FlagCRLFile)
+			if err != nil {
+				return err
+			}
+
+			if file == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			return os.WriteFile(file, data, 0o644) // nolint: gosec
+		},
+	}
+
+	cmd.Flags().Int64(FlagCRLSinceHeight, 0, "List certificates revoked at or after this height")
+	cmd.Flags().String(FlagCRLFormat, crlFormatJSON, "Output format: crl|json")
+	cmd.Flags().String(FlagCRLFile, "", "Write revocation list to the file instead of stdout")
+	cmd.Flags().String(FlagCRLIssuerCert, "", "PEM encoded certificate of the CRL issuer (crl format only)")
+	cmd.Flags().String(FlagCRLIssuerKey, "", "PEM encoded private key of the CRL issuer (crl format only)")
+	cmd.Flags().Duration(FlagCRLNextUpdate, time.Hour, "Interval until the next CRL update (crl format only)")
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// queryCertRevocations reads all pages of the revocation list. Pages following the first one
+// are queried at the height of the first page so the list is consistent.
+func queryCertRevocations(cmd *cobra.Command, cctx sdkclient.Context, since int64) (certRevocationDigest, error) {
+	digest := certRevocationDigest{
+		SinceHeight: since,
+		Revocations: []certRevocation{},
+	}
+
+	var key []byte
+
+	for {
+		res, err := ctypes.NewQueryClient(cctx).CertificateRevocations(cmd.Context(), &ctypes.QueryCertificateRevocationsRequest{
+			SinceHeight: since,
+			Pagination: &sdkquery.PageRequest{
+				Key: key,
+			},
+		})
+		if err != nil {
+			return certRevocationDigest{}, err
+		}
+
+		if digest.Height == 0 {
+			digest.Height = res.Height
+			cctx = cctx.WithHeight(res.Height)
+		}
+
+		for _, rev := range res.Revocations {
+			digest.Revocations = append(digest.Revocations, certRevocation{
+				Owner:     rev.Owner,
+				Serial:    rev.Serial,
+				Height:    rev.Height,
+				RevokedAt: rev.RevokedAt,
+			})
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+
+		key = res.Pagination.NextKey
+	}
+
+	return digest, nil
+}
+
+func certRevocationsToCRL(cmd *cobra.Command, digest certRevocationDigest) ([]byte, error) {
+	certFile, err := cmd.Flags().GetString(FlagCRLIssuerCert)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFile, err := cmd.Flags().GetString(FlagCRLIssuerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nextUpdate, err := cmd.Flags().GetDuration(FlagCRLNextUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("%w: --%s and --%s are required for crl format", ErrCRLIssuer, FlagCRLIssuerCert, FlagCRLIssuerKey)
+	}
+
+	issuer, signer, err := loadCRLIssuer(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]x509.RevocationListEntry, 0, len(digest.Revocations))
+
+	for _, rev := range digest.Revocations {
+		serial, valid := new(big.Int).SetString(rev.Serial, 10)
+		if !valid {
+			return nil, fmt.Errorf("invalid certificate serial \"%s\"", rev.Serial)
+		}
+
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: rev.RevokedAt,
+		})
+	}
+
+	now := time.Now().UTC()
+
+	tmpl := &x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		Number:                    big.NewInt(digest.Height),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(nextUpdate),
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, issuer, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "X509 CRL",
+		Bytes: der,
+	}), nil
+}
+
+func loadCRLIssuer(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	certData, err := os.ReadFile(certFile) // nolint: gosec
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return nil, nil, fmt.Errorf("%w: no PEM data in %s", ErrCRLIssuer, certFile)
+	}
+
+	issuer, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyData, err := os.ReadFile(keyFile) // nolint: gosec
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ = pem.Decode(keyData)
+	if block == nil {
+		return nil, nil, fmt.Errorf("%w: no PEM data in %s", ErrCRLIssuer, keyFile)
+	}
+
+	var key any
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, valid := key.(crypto.Signer)
+	if !valid {
+		return nil, nil, fmt.Errorf("%w: unsupported private key type %T", ErrCRLIssuer, key)
+	}
+
+	return issuer, signer, nil
+}
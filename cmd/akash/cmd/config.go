@@ -3,24 +3,28 @@ package cmd
 import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
+
+	"pkg.akt.dev/node/v2/indexer"
 )
 
 type AppConfig struct {
 	serverconfig.Config
 
 	WasmConfig wasmtypes.NodeConfig `mapstructure:"wasm"`
+	Indexer    indexer.Config       `mapstructure:"indexer"`
 }
 
 var AppTemplate = serverconfig.DefaultConfigTemplate + `
 ###############################################################################
 ###                            Wasm Configuration                           ###
 ###############################################################################
-` + wasmtypes.DefaultConfigTemplate()
+` + wasmtypes.DefaultConfigTemplate() + indexer.ConfigTemplate
 
 func InitAppConfig() (string, interface{}) {
 	appCfg := AppConfig{
 		Config:     *serverconfig.DefaultConfig(),
 		WasmConfig: wasmtypes.DefaultNodeConfig(),
+		Indexer:    indexer.DefaultConfig(),
 	}
 
 	appCfg.MinGasPrices = "0.0025uakt"
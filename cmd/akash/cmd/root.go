@@ -21,6 +21,7 @@ import (
 
 	"pkg.akt.dev/node/v2/app"
 	"pkg.akt.dev/node/v2/cmd/akash/cmd/testnetify"
+	"pkg.akt.dev/node/v2/util/server"
 )
 
 // NewRootCmd creates a new root command for akash. It is called once in the
@@ -65,10 +66,12 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig sdkutil.EncodingConfig)
 
 	debugCmd := debug.Cmd()
 	debugCmd.AddCommand(ConvertBech32Cmd())
+	debugCmd.AddCommand(StateDiffCmd(ac.newApp, home))
 
 	rootCmd.AddCommand(
 		sdkserver.StatusCommand(),
 		AuthCmd(),
+		CertCmd(),
 		cli.EventsCmd(),
 		cli.QueryCmd(),
 		cli.TxCmd(),
@@ -82,10 +85,19 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig sdkutil.EncodingConfig)
 		testnetCmd(app.ModuleBasics(), banktypes.GenesisBalancesIterator{}),
 		PrepareGenesisCmd(app.DefaultHome, app.ModuleBasics()),
 		testnetify.GetCmd(ac.newTestnetApp),
+		UpgradeCmd(encodingConfig, home),
 	)
 
 	cli.ServerCmds(rootCmd, home, ac.newApp, ac.appExport, addModuleInitFlags)
 
+	// replace export command with the one streaming app state
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "export" {
+			rootCmd.RemoveCommand(c)
+		}
+	}
+	rootCmd.AddCommand(server.ExportCmd(ac.appExportStream, home))
+
 	rootCmd.SetOut(rootCmd.OutOrStdout())
 	rootCmd.SetErr(rootCmd.ErrOrStderr())
 }
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/schema"
+	dbm "github.com/cosmos/cosmos-db"
+	sdkserver "github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/spf13/cobra"
+
+	cflags "pkg.akt.dev/go/cli/flags"
+
+	akash "pkg.akt.dev/node/v2/app"
+	"pkg.akt.dev/node/v2/util/server"
+	"pkg.akt.dev/node/v2/util/statediff"
+)
+
+const (
+	flagStateDiffFrom   = "from"
+	flagStateDiffTo     = "to"
+	flagStateDiffModule = "module"
+)
+
+// changeDecoder returns human readable form of a changed key, or empty string if change cannot be decoded
+type changeDecoder func(statediff.Change) string
+
+// StateDiffCmd prints keys added, removed or changed in module stores between two heights
+func StateDiffCmd(appCreator servertypes.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff",
+		Short: "Print keys changed in module stores between two heights",
+		Long: `Print keys added, removed or changed in module stores between two heights.
+The node must be stopped, application database is opened at both heights and nothing is written to it.
+Values are decoded by module collections schema or simulation store decoder when available.
+Example:
+	akash debug state-diff --from 100 --to 101 --module market --module deployment
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sctx := sdkserver.GetServerContextFromCmd(cmd)
+			config := sctx.Config
+
+			homeDir, _ := cmd.Flags().GetString(cflags.FlagHome)
+			config.SetRoot(homeDir)
+
+			from, _ := cmd.Flags().GetInt64(flagStateDiffFrom)
+			to, _ := cmd.Flags().GetInt64(flagStateDiffTo)
+			modules, _ := cmd.Flags().GetStringSlice(flagStateDiffModule)
+
+			if from <= 0 || to <= 0 {
+				return fmt.Errorf("both --%s and --%s heights must be set", flagStateDiffFrom, flagStateDiffTo)
+			}
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(sctx.Viper), filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = db.Close()
+			}()
+
+			app, ok := appCreator(log.NewNopLogger(), db, nil, sctx.Viper).(*akash.AkashApp)
+			if !ok {
+				return fmt.Errorf("app created from app creator is not of type AkashApp")
+			}
+
+			fromStore, err := app.CommitMultiStore().CacheMultiStoreWithVersion(from)
+			if err != nil {
+				return fmt.Errorf("failed to load state at height %d: %w", from, err)
+			}
+
+			toStore, err := app.CommitMultiStore().CacheMultiStoreWithVersion(to)
+			if err != nil {
+				return fmt.Errorf("failed to load state at height %d: %w", to, err)
+			}
+
+			skeys := app.GetKVStoreKey()
+
+			names := modules
+			if len(names) == 0 {
+				for name := range skeys {
+					names = append(names, name)
+				}
+			}
+
+			slices.Sort(names)
+
+			out := cmd.OutOrStdout()
+
+			for _, name := range names {
+				skey, found := skeys[name]
+				if !found {
+					return fmt.Errorf("unknown module store %q", name)
+				}
+
+				decode := stateDiffDecoder(app, name)
+
+				err = statediff.Diff(fromStore.GetKVStore(skey), toStore.GetKVStore(skey), func(change statediff.Change) error {
+					return writeStateChange(out, name, change, decode)
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cflags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(flagStateDiffFrom, 0, "Height of the base state")
+	cmd.Flags().Int64(flagStateDiffTo, 0, "Height of the state compared to the base one")
+	cmd.Flags().StringSlice(flagStateDiffModule, []string{}, "Module store to compare, may be repeated. If empty, all stores are compared")
+
+	return cmd
+}
+
+func writeStateChange(w io.Writer, store string, change statediff.Change, decode changeDecoder) error {
+	line := fmt.Sprintf("%s %s %s", store, change.Kind, strings.ToUpper(hex.EncodeToString(change.Key)))
+
+	if desc := decode(change); desc != "" {
+		line += " " + desc
+	} else {
+		line += fmt.Sprintf(" %X -> %X", change.From, change.To)
+	}
+
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// stateDiffDecoder returns decoder of the module store. Module collections schema is preferred
+// over simulation store decoder, changes of stores with neither are printed raw.
+func stateDiffDecoder(app *akash.AkashApp, name string) changeDecoder {
+	if mod, found := app.MM.Modules[name]; found {
+		if hasCodec, ok := mod.(schema.HasModuleCodec); ok {
+			if mcdc, err := hasCodec.ModuleCodec(); err == nil && mcdc.KVDecoder != nil {
+				return schemaChangeDecoder(mcdc.KVDecoder)
+			}
+		}
+	}
+
+	if sm := app.SimulationManager(); sm != nil {
+		if decoder, found := sm.StoreDecoders[name]; found {
+			return func(change statediff.Change) (res string) {
+				// simulation decoders panic on keys they do not know
+				defer func() {
+					if r := recover(); r != nil {
+						res = ""
+					}
+				}()
+
+				return decoder(kv.Pair{Key: change.Key, Value: change.From}, kv.Pair{Key: change.Key, Value: change.To})
+			}
+		}
+	}
+
+	return func(statediff.Change) string {
+		return ""
+	}
+}
+
+func schemaChangeDecoder(decoder schema.KVDecoder) changeDecoder {
+	describe := func(key, value []byte, remove bool) string {
+		updates, err := decoder(schema.KVPairUpdate{Key: key, Value: value, Remove: remove})
+		if err != nil || len(updates) == 0 {
+			return ""
+		}
+
+		parts := make([]string, 0, len(updates))
+		for _, update := range updates {
+			if update.Delete {
+				parts = append(parts, fmt.Sprintf("%s %v", update.TypeName, update.Key))
+			} else {
+				parts = append(parts, fmt.Sprintf("%s %v = %v", update.TypeName, update.Key, update.Value))
+			}
+		}
+
+		return strings.Join(parts, "; ")
+	}
+
+	return func(change statediff.Change) string {
+		switch change.Kind {
+		case statediff.Added:
+			return describe(change.Key, change.To, false)
+		case statediff.Removed:
+			return describe(change.Key, change.From, true)
+		default:
+			from := describe(change.Key, change.From, false)
+			to := describe(change.Key, change.To, false)
+
+			if from == "" || to == "" {
+				return ""
+			}
+
+			return from + " -> " + to
+		}
+	}
+}
@@ -59,11 +59,14 @@ type TestnetValidator struct {
 type TestnetValidators []TestnetValidator
 
 type TestnetConfig struct {
-	ChainID    string                 `json:"chain_id"`
-	Validators TestnetValidators      `json:"validators"`
-	Accounts   []akash.TestnetAccount `json:"accounts"`
-	Gov        akash.TestnetGovConfig `json:"gov"`
-	upgrade    akash.TestnetUpgrade
+	ChainID     string                   `json:"chain_id"`
+	Validators  TestnetValidators        `json:"validators"`
+	Accounts    []akash.TestnetAccount   `json:"accounts"`
+	Gov         akash.TestnetGovConfig   `json:"gov"`
+	Oracle      akash.TestnetOracle      `json:"oracle"`
+	BME         akash.TestnetBME         `json:"bme"`
+	Marketplace akash.TestnetMarketplace `json:"marketplace"`
+	upgrade     akash.TestnetUpgrade
 }
 
 func TrimQuotes(data string) string {
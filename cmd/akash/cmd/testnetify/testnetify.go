@@ -349,10 +349,14 @@ func testnetify(sctx *sdksrv.Context, tcfg TestnetConfig, testnetAppCreator type
 	}
 
 	appConfig := &akash.TestnetConfig{
-		Accounts:   tcfg.Accounts,
-		Gov:        tcfg.Gov,
-		Validators: make([]akash.TestnetValidator, 0, len(tcfg.Validators)),
-		Upgrade:    tcfg.upgrade,
+		Accounts:    tcfg.Accounts,
+		Gov:         tcfg.Gov,
+		Validators:  make([]akash.TestnetValidator, 0, len(tcfg.Validators)),
+		Upgrade:     tcfg.upgrade,
+		Oracle:      tcfg.Oracle,
+		BME:         tcfg.BME,
+		Marketplace: tcfg.Marketplace,
+		BlockTime:   state.LastBlockTime,
 	}
 
 	for i, val := range tcfg.Validators {
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtstore "github.com/cometbft/cometbft/store"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdkserver "github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	cflags "pkg.akt.dev/go/cli/flags"
+	"pkg.akt.dev/go/sdkutil"
+
+	akash "pkg.akt.dev/node/v2/app"
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+	"pkg.akt.dev/node/v2/util/server"
+	"pkg.akt.dev/node/v2/util/statediff"
+	"pkg.akt.dev/node/v2/util/storeprofile"
+)
+
+// UpgradeCmd returns software upgrade related commands
+func UpgradeCmd(encodingConfig sdkutil.EncodingConfig, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Software upgrade subcommands",
+	}
+
+	cmd.AddCommand(UpgradeDryRunCmd(encodingConfig, defaultNodeHome))
+
+	return cmd
+}
+
+// UpgradeDryRunCmd runs registered software upgrade on top of the latest state without committing it
+func UpgradeDryRunCmd(encodingConfig sdkutil.EncodingConfig, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dry-run [upgrade-name]",
+		Short: "Run software upgrade on the latest state without committing it",
+		Long: `Run store loader and upgrade handler of the registered software upgrade, including module migrations,
+on the latest state as if upgrade was scheduled at the next height. Nothing is committed,
+however store upgrades are applied to the working state on load, so run it against a copy of the data directory.
+Reports consensus version changes, per module gas, time and changed keys, and invariants broken by the upgrade.
+Example:
+	akash upgrade dry-run v2.2.0 --home /tmp/akash-copy
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			initFn, found := utypes.GetUpgradesList()[name]
+			if !found {
+				names := make([]string, 0, len(utypes.GetUpgradesList()))
+				for name := range utypes.GetUpgradesList() {
+					names = append(names, name)
+				}
+				slices.Sort(names)
+
+				return fmt.Errorf("upgrade %q is not registered, available upgrades: %s", name, strings.Join(names, ", "))
+			}
+
+			sctx := sdkserver.GetServerContextFromCmd(cmd)
+			config := sctx.Config
+
+			homeDir, _ := cmd.Flags().GetString(cflags.FlagHome)
+			config.SetRoot(homeDir)
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(sctx.Viper), filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = db.Close()
+			}()
+
+			height := rootmulti.GetLatestVersion(db) + 1
+
+			blockTime, err := lastBlockTime(config, height-1)
+			if err != nil {
+				return err
+			}
+
+			app := akash.NewApp(log.NewNopLogger(), db, nil, false, 1, map[int64]bool{}, encodingConfig, sctx.Viper)
+
+			upgrade, err := initFn(app.Logger(), app.App)
+			if err != nil {
+				return fmt.Errorf("unable to initialize upgrade `%s`: %w", name, err)
+			}
+
+			if storeUpgrades := upgrade.StoreLoader(); storeUpgrades != nil {
+				app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(height, storeUpgrades))
+			}
+
+			if err = app.LoadLatestVersion(); err != nil {
+				return err
+			}
+
+			app.MM.RegisterInvariants(app.Keepers.Cosmos.Crisis)
+
+			cache := app.CommitMultiStore().CacheMultiStore()
+			profiler := storeprofile.NewProfiler()
+
+			ctx := sdk.NewContext(profiler.CacheMultiStore(cache), cmproto.Header{
+				ChainID: app.ChainID(),
+				Height:  height,
+				Time:    blockTime,
+			}, false, app.Logger()).WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+			fromVM, err := app.Keepers.Cosmos.Upgrade.GetModuleVersionMap(ctx)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			toVM, upgradeErr := upgrade.UpgradeHandler()(ctx, upgradetypes.Plan{Name: name, Height: height}, fromVM)
+			elapsed := time.Since(start)
+
+			stats := profiler.Stats()
+
+			out := cmd.OutOrStdout()
+
+			if upgradeErr != nil {
+				_, _ = fmt.Fprintf(out, "upgrade %s at height %d failed after %s: %s\n", name, height, elapsed, upgradeErr)
+			} else {
+				_, _ = fmt.Fprintf(out, "upgrade %s at height %d succeeded in %s, gas used %d\n", name, height, elapsed, ctx.GasMeter().GasConsumed())
+			}
+
+			if err = writeVersionChanges(out, fromVM, toVM); err != nil {
+				return err
+			}
+
+			if err = writeModuleChanges(out, app, cache, stats); err != nil {
+				return err
+			}
+
+			broken := 0
+			if upgradeErr == nil {
+				broken, err = writeInvariants(out, app, ctx)
+				if err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case upgradeErr != nil:
+				return upgradeErr
+			case broken > 0:
+				return fmt.Errorf("upgrade %s broke %d invariant(s)", name, broken)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cflags.FlagHome, defaultNodeHome, "The application home directory")
+
+	return cmd
+}
+
+func lastBlockTime(config *cmtcfg.Config, height int64) (time.Time, error) {
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	blockStore := cmtstore.NewBlockStore(blockStoreDB)
+
+	defer func() {
+		_ = blockStore.Close()
+	}()
+
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return time.Time{}, fmt.Errorf("block %d not found in block store", height)
+	}
+
+	return meta.Header.Time, nil
+}
+
+func writeVersionChanges(w io.Writer, fromVM, toVM module.VersionMap) error {
+	modules := make([]string, 0, len(toVM))
+	for name, version := range toVM {
+		if fromVM[name] != version {
+			modules = append(modules, name)
+		}
+	}
+
+	slices.Sort(modules)
+
+	_, _ = fmt.Fprintln(w, "\nconsensus versions:")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  MODULE\tFROM\tTO")
+
+	for _, name := range modules {
+		from := "-"
+		if version, found := fromVM[name]; found {
+			from = fmt.Sprint(version)
+		}
+
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%d\n", name, from, toVM[name])
+	}
+
+	return tw.Flush()
+}
+
+func writeModuleChanges(w io.Writer, app *akash.AkashApp, cache storetypes.CacheMultiStore, stats map[string]storeprofile.Stats) error {
+	skeys := app.GetKVStoreKey()
+
+	names := make([]string, 0, len(skeys))
+	for name := range skeys {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	_, _ = fmt.Fprintln(w, "\nmodules:")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  MODULE\tGAS\tTIME\tADDED\tREMOVED\tCHANGED")
+
+	for _, name := range names {
+		counts := make(map[statediff.Kind]int)
+
+		err := statediff.Diff(app.CommitMultiStore().GetKVStore(skeys[name]), cache.GetKVStore(skeys[name]), func(change statediff.Change) error {
+			counts[change.Kind]++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		st, accessed := stats[name]
+		if !accessed && len(counts) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%s\t%d\t%d\t%d\n", name, st.Gas, st.Duration, counts[statediff.Added], counts[statediff.Removed], counts[statediff.Changed])
+	}
+
+	return tw.Flush()
+}
+
+func writeInvariants(w io.Writer, app *akash.AkashApp, ctx sdk.Context) (int, error) {
+	_, _ = fmt.Fprintln(w, "\ninvariants:")
+
+	broken := 0
+
+	for _, route := range app.Keepers.Cosmos.Crisis.Routes() {
+		res, isBroken := route.Invar(ctx)
+
+		status := "ok"
+		if isBroken {
+			broken++
+			status = "BROKEN " + strings.TrimSpace(res)
+		}
+
+		if _, err := fmt.Fprintf(w, "  %s: %s\n", route.FullRoute(), status); err != nil {
+			return broken, err
+		}
+	}
+
+	return broken, nil
+}
@@ -0,0 +1,212 @@
+package indexer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// listQuery describes listing of indexed table.
+// filters maps query parameters to columns matched for equality.
+type listQuery struct {
+	table   string
+	columns []string
+	filters map[string]string
+	order   string
+}
+
+var (
+	deploymentsQuery = listQuery{
+		table:   "deployments",
+		columns: []string{"owner", "dseq", "state", "hash", "created_height", "updated_height", "closed_height"},
+		filters: map[string]string{"owner": "owner", "state": "state"},
+		order:   "created_height DESC, owner, dseq",
+	}
+	leasesQuery = listQuery{
+		table: "leases",
+		columns: []string{"owner", "dseq", "gseq", "oseq", "provider", "bseq", "state", "price_denom", "price_amount",
+			"created_height", "closed_height", "close_reason"},
+		filters: map[string]string{"owner": "owner", "dseq": "dseq", "provider": "provider", "state": "state"},
+		order:   "created_height DESC, owner, dseq, gseq, oseq, provider, bseq",
+	}
+	paymentsQuery = listQuery{
+		table:   "payments",
+		columns: []string{"id", "owner", "state", "rate", "balance", "unsettled", "withdrawn", "updated_height"},
+		filters: map[string]string{"owner": "owner", "state": "state"},
+		order:   "updated_height DESC, id",
+	}
+	pricesQuery = listQuery{
+		table:   "prices",
+		columns: []string{"height", "source", "denom", "base_denom", "price", "timestamp"},
+		filters: map[string]string{"source": "source", "denom": "denom", "base_denom": "base_denom"},
+		order:   "height DESC, idx DESC",
+	}
+	ledgerQuery = listQuery{
+		table: "bme_ledger",
+		columns: []string{"denom", "to_denom", "source", "height", "sequence", "burned_from", "minted_to",
+			"burned", "burned_price", "minted", "minted_price", "executed_height"},
+		filters: map[string]string{"source": "source", "denom": "denom", "to_denom": "to_denom"},
+		order:   "executed_height DESC, height DESC, sequence DESC",
+	}
+	eventsQuery = listQuery{
+		table:   "events",
+		columns: []string{"height", "idx", "tx_index", "type", "attributes"},
+		filters: map[string]string{"height": "height", "type": "type"},
+		order:   "height DESC, idx",
+	}
+)
+
+// api is read-only HTTP query API of the indexed data
+type api struct {
+	db *sql.DB
+}
+
+func newAPI(db *sql.DB) *api {
+	return &api{db: db}
+}
+
+func (a *api) router() http.Handler {
+	rtr := mux.NewRouter()
+
+	v1 := rtr.PathPrefix("/v1").Methods(http.MethodGet).Subrouter()
+
+	v1.HandleFunc("/status", a.status)
+	v1.HandleFunc("/deployments", a.list(deploymentsQuery))
+	v1.HandleFunc("/leases", a.list(leasesQuery))
+	v1.HandleFunc("/payments", a.list(paymentsQuery))
+	v1.HandleFunc("/prices", a.list(pricesQuery))
+	v1.HandleFunc("/bme/ledger", a.list(ledgerQuery))
+	v1.HandleFunc("/events", a.list(eventsQuery))
+
+	return rtr
+}
+
+func (a *api) status(w http.ResponseWriter, r *http.Request) {
+	var height sql.NullInt64
+
+	if err := a.db.QueryRowContext(r.Context(), `SELECT MAX(height) FROM blocks`).Scan(&height); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, map[string]any{"height": height.Int64})
+}
+
+func (a *api) list(q listQuery) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+
+		limit, err := intParam(params.Get("limit"), defaultLimit)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("limit must be within 1..%d", maxLimit))
+			return
+		}
+
+		offset, err := intParam(params.Get("offset"), 0)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("offset must not be negative"))
+			return
+		}
+
+		var where []string
+		var args []any
+
+		for param := range params {
+			column, found := q.filters[param]
+			if !found {
+				continue
+			}
+
+			args = append(args, params.Get(param))
+			where = append(where, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+
+		stmt := fmt.Sprintf("SELECT %s FROM %s", strings.Join(q.columns, ", "), q.table)
+		if len(where) > 0 {
+			stmt += " WHERE " + strings.Join(where, " AND ")
+		}
+
+		args = append(args, limit, offset)
+		stmt += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", q.order, len(args)-1, len(args))
+
+		items, err := a.query(r, q.columns, stmt, args...)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, map[string]any{"items": items})
+	}
+}
+
+func (a *api) query(r *http.Request, columns []string, stmt string, args ...any) ([]map[string]any, error) {
+	rows, err := a.db.QueryContext(r.Context(), stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	items := make([]map[string]any, 0)
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+
+		if err = rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		item := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if bz, ok := values[i].([]byte); ok {
+				values[i] = string(bz)
+			}
+
+			item[column] = values[i]
+		}
+
+		// events attributes are stored as JSON object
+		if attrs, ok := item["attributes"].(string); ok {
+			item["attributes"] = json.RawMessage(attrs)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func intParam(val string, def int) (int, error) {
+	if val == "" {
+		return def, nil
+	}
+
+	return strconv.Atoi(val)
+}
+
+func writeJSON(w http.ResponseWriter, val any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(val)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,157 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	dv1 "pkg.akt.dev/go/node/deployment/v1"
+	emodule "pkg.akt.dev/go/node/escrow/module"
+	etypes "pkg.akt.dev/go/node/escrow/types/v1"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	otypes "pkg.akt.dev/go/node/oracle/v2"
+
+	ekeeper "pkg.akt.dev/node/v2/x/escrow/keeper"
+)
+
+const (
+	// eventTypePrefix selects events recorded into events table
+	eventTypePrefix = "akash."
+
+	// block events are recorded with tx index -1
+	blockEventTxIndex = -1
+)
+
+// typedEvents are events decoded into dedicated tables
+var typedEvents = map[string]bool{
+	proto.MessageName(&dv1.EventDeploymentCreated{}):         true,
+	proto.MessageName(&dv1.EventDeploymentUpdated{}):         true,
+	proto.MessageName(&dv1.EventDeploymentClosed{}):          true,
+	proto.MessageName(&mv1.EventLeaseCreated{}):              true,
+	proto.MessageName(&mv1.EventLeaseClosed{}):               true,
+	proto.MessageName(&otypes.EventPriceData{}):              true,
+	proto.MessageName(&bmetypes.EventLedgerRecordExecuted{}): true,
+}
+
+// attributes added by baseapp to every event, they are not part of typed events
+var untypedAttributes = map[string]bool{
+	"msg_index": true,
+	"mode":      true,
+}
+
+type event struct {
+	// idx is index of the event within the block
+	idx int
+	// txIndex is index of the transaction emitted the event or -1 for events emitted by begin and end blockers
+	txIndex    int
+	kind       string
+	attributes string
+	// msg is decoded typed event, nil if event is not indexed into dedicated table
+	msg proto.Message
+}
+
+type payment struct {
+	id    string
+	state etypes.PaymentState
+}
+
+type block struct {
+	height   int64
+	time     string
+	events   []event
+	payments []payment
+}
+
+// decodeEvents collects akash events emitted by the block, events of block phases first, then events of transactions
+func decodeEvents(res abci.ResponseFinalizeBlock) ([]event, error) {
+	var events []event
+
+	add := func(txIndex int, evs []abci.Event) error {
+		for _, ev := range evs {
+			if !strings.HasPrefix(ev.Type, eventTypePrefix) {
+				continue
+			}
+
+			attrs := make(map[string]string, len(ev.Attributes))
+			typed := abci.Event{Type: ev.Type}
+
+			for _, attr := range ev.Attributes {
+				attrs[attr.Key] = attr.Value
+				if !untypedAttributes[attr.Key] {
+					typed.Attributes = append(typed.Attributes, attr)
+				}
+			}
+
+			bz, err := json.Marshal(attrs)
+			if err != nil {
+				return err
+			}
+
+			rec := event{
+				idx:        len(events),
+				txIndex:    txIndex,
+				kind:       ev.Type,
+				attributes: string(bz),
+			}
+
+			if typedEvents[ev.Type] {
+				if rec.msg, err = sdk.ParseTypedEvent(typed); err != nil {
+					return err
+				}
+			}
+
+			events = append(events, rec)
+		}
+
+		return nil
+	}
+
+	if err := add(blockEventTxIndex, res.Events); err != nil {
+		return nil, err
+	}
+
+	for i, tx := range res.TxResults {
+		if err := add(i, tx.Events); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// decodePayments collects escrow payments written by the block.
+// Payment moving to another state is deleted under the key of the previous state, so deletes are ignored.
+func decodePayments(cdc codec.BinaryCodec, changeSet []*storetypes.StoreKVPair) ([]payment, error) {
+	var payments []payment
+
+	for _, pair := range changeSet {
+		if pair.StoreKey != emodule.StoreKey || pair.Delete || !bytes.HasPrefix(pair.Key, ekeeper.PaymentPrefix) {
+			continue
+		}
+
+		id, _, err := ekeeper.ParsePaymentKey(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		var state etypes.PaymentState
+		if err = cdc.Unmarshal(pair.Value, &state); err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment{
+			id:    id.Key(),
+			state: state,
+		})
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,78 @@
+package indexer
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dv1 "pkg.akt.dev/go/node/deployment/v1"
+	emodule "pkg.akt.dev/go/node/escrow/module"
+	etypes "pkg.akt.dev/go/node/escrow/types/v1"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	"pkg.akt.dev/go/testutil"
+
+	ekeeper "pkg.akt.dev/node/v2/x/escrow/keeper"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	lid := testutil.LeaseID(t)
+
+	created, err := sdk.TypedEventToEvent(&dv1.EventDeploymentCreated{ID: lid.DeploymentID(), Hash: []byte{0x01}})
+	require.NoError(t, err)
+
+	closed, err := sdk.TypedEventToEvent(&mv1.EventLeaseClosed{ID: lid})
+	require.NoError(t, err)
+
+	// attributes appended by baseapp must not break decoding of typed events
+	created.Attributes = append(created.Attributes, abci.EventAttribute{Key: "msg_index", Value: "0"})
+	closed.Attributes = append(closed.Attributes, abci.EventAttribute{Key: "mode", Value: "EndBlock"})
+
+	events, err := decodeEvents(abci.ResponseFinalizeBlock{
+		Events: []abci.Event{abci.Event(closed), {Type: "transfer"}},
+		TxResults: []*abci.ExecTxResult{
+			{Events: []abci.Event{{Type: "message"}, abci.Event(created)}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, 0, events[0].idx)
+	require.Equal(t, blockEventTxIndex, events[0].txIndex)
+	require.Equal(t, closed.Type, events[0].kind)
+	require.Equal(t, &mv1.EventLeaseClosed{ID: lid}, events[0].msg)
+
+	require.Equal(t, 1, events[1].idx)
+	require.Equal(t, 0, events[1].txIndex)
+	require.Contains(t, events[1].attributes, `"msg_index":"0"`)
+	require.Equal(t, &dv1.EventDeploymentCreated{ID: lid.DeploymentID(), Hash: []byte{0x01}}, events[1].msg)
+}
+
+func TestDecodePayments(t *testing.T) {
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	pid := testutil.LeaseID(t).ToEscrowPaymentID()
+	state := etypes.PaymentState{
+		Owner:     testutil.AccAddress(t).String(),
+		State:     etypes.StateOpen,
+		Rate:      sdk.NewDecCoin("uakt", sdkmath.NewInt(10)),
+		Balance:   sdk.NewDecCoin("uakt", sdkmath.NewInt(100)),
+		Unsettled: sdk.NewDecCoin("uakt", sdkmath.ZeroInt()),
+		Withdrawn: sdk.NewCoin("uakt", sdkmath.ZeroInt()),
+	}
+
+	payments, err := decodePayments(cdc, []*storetypes.StoreKVPair{
+		{StoreKey: emodule.StoreKey, Key: ekeeper.BuildPaymentsKey(etypes.StateOverdrawn, &pid), Delete: true},
+		{StoreKey: emodule.StoreKey, Key: ekeeper.BuildPaymentsKey(etypes.StateOpen, &pid), Value: cdc.MustMarshal(&state)},
+		{StoreKey: emodule.StoreKey, Key: append([]byte{}, ekeeper.AccountPrefix...), Value: []byte{0x01}},
+		{StoreKey: "bank", Key: ekeeper.BuildPaymentsKey(etypes.StateOpen, &pid), Value: []byte{0x01}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []payment{{id: pid.Key(), state: state}}, payments)
+}
@@ -0,0 +1,162 @@
+// Package indexer implements optional ABCI listener which indexes marketplace events and escrow payments
+// into SQL database, and serves read-only query API on top of it.
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	// postgres driver
+	_ "github.com/lib/pq"
+)
+
+// Indexer is ABCI listener writing blocks to the database on commit
+type Indexer struct {
+	cfg    Config
+	cdc    codec.BinaryCodec
+	log    log.Logger
+	store  *store
+	server *http.Server
+
+	lock    sync.Mutex
+	started bool
+	pending *block
+}
+
+var _ storetypes.ABCIListener = (*Indexer)(nil)
+
+// New returns indexer writing to the database configured by cfg.
+// Connection to the database and query API are started with the first finalized block,
+// so indexer does not do anything in processes which never finalize blocks, e.g. queries or export.
+func New(cfg Config, cdc codec.BinaryCodec, logger log.Logger) (*Indexer, error) {
+	if cfg.DSN == "" {
+		return nil, errors.New("indexer: dsn is not set")
+	}
+
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("indexer: %w", err)
+	}
+
+	return &Indexer{
+		cfg:   cfg,
+		cdc:   cdc,
+		log:   logger.With("module", "indexer"),
+		store: &store{db: db},
+	}, nil
+}
+
+// ListenFinalizeBlock decodes events of the block, they are written to the database once block is committed
+func (idx *Indexer) ListenFinalizeBlock(ctx context.Context, req abci.RequestFinalizeBlock, res abci.ResponseFinalizeBlock) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.pending = nil
+
+	if err := idx.start(ctx); err != nil {
+		return err
+	}
+
+	events, err := decodeEvents(res)
+	if err != nil {
+		return fmt.Errorf("indexer: block %d: %w", req.Height, err)
+	}
+
+	idx.pending = &block{
+		height: req.Height,
+		time:   req.Time.UTC().Format(time.RFC3339Nano),
+		events: events,
+	}
+
+	return nil
+}
+
+// ListenCommit writes block finalized last together with escrow payments changed by it
+func (idx *Indexer) ListenCommit(ctx context.Context, _ abci.ResponseCommit, changeSet []*storetypes.StoreKVPair) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	b := idx.pending
+	idx.pending = nil
+
+	if b == nil {
+		return nil
+	}
+
+	var err error
+
+	b.payments, err = decodePayments(idx.cdc, changeSet)
+	if err != nil {
+		return fmt.Errorf("indexer: block %d: %w", b.height, err)
+	}
+
+	if err = idx.store.write(ctx, b); err != nil {
+		return fmt.Errorf("indexer: block %d: %w", b.height, err)
+	}
+
+	return nil
+}
+
+// Close stops query API and closes the database
+func (idx *Indexer) Close() error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	var err error
+
+	if idx.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err = idx.server.Shutdown(ctx)
+	}
+
+	return errors.Join(err, idx.store.db.Close())
+}
+
+// start creates schema and starts query API, it is retried with every block until it succeeds
+func (idx *Indexer) start(ctx context.Context) error {
+	if idx.started {
+		return nil
+	}
+
+	if err := idx.store.init(ctx); err != nil {
+		return err
+	}
+
+	if idx.cfg.APIAddress != "" {
+		ln, err := net.Listen("tcp", idx.cfg.APIAddress)
+		if err != nil {
+			return fmt.Errorf("indexer api: %w", err)
+		}
+
+		idx.server = &http.Server{
+			Handler:           newAPI(idx.store.db).router(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			if err := idx.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				idx.log.Error("query api stopped", "err", err)
+			}
+		}()
+
+		idx.log.Info("query api started", "address", ln.Addr().String())
+	}
+
+	idx.started = true
+
+	return nil
+}
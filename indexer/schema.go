@@ -0,0 +1,83 @@
+package indexer
+
+// schema is created on start of the indexer. Statements use SQL understood by both Postgres and SQLite,
+// amounts and prices are stored as text to keep their precision, time is stored in RFC3339 form.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		height BIGINT PRIMARY KEY,
+		time TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS events (
+		height BIGINT NOT NULL,
+		idx INTEGER NOT NULL,
+		tx_index INTEGER NOT NULL,
+		type TEXT NOT NULL,
+		attributes TEXT NOT NULL,
+		PRIMARY KEY (height, idx)
+	)`,
+	`CREATE INDEX IF NOT EXISTS events_type ON events (type, height)`,
+	`CREATE TABLE IF NOT EXISTS deployments (
+		owner TEXT NOT NULL,
+		dseq BIGINT NOT NULL,
+		state TEXT NOT NULL,
+		hash TEXT NOT NULL,
+		created_height BIGINT NOT NULL,
+		updated_height BIGINT NOT NULL,
+		closed_height BIGINT,
+		PRIMARY KEY (owner, dseq)
+	)`,
+	`CREATE TABLE IF NOT EXISTS leases (
+		owner TEXT NOT NULL,
+		dseq BIGINT NOT NULL,
+		gseq INTEGER NOT NULL,
+		oseq INTEGER NOT NULL,
+		provider TEXT NOT NULL,
+		bseq INTEGER NOT NULL,
+		state TEXT NOT NULL,
+		price_denom TEXT NOT NULL,
+		price_amount TEXT NOT NULL,
+		created_height BIGINT NOT NULL,
+		closed_height BIGINT,
+		close_reason TEXT,
+		PRIMARY KEY (owner, dseq, gseq, oseq, provider, bseq)
+	)`,
+	`CREATE INDEX IF NOT EXISTS leases_provider ON leases (provider, state)`,
+	`CREATE TABLE IF NOT EXISTS payments (
+		id TEXT PRIMARY KEY,
+		owner TEXT NOT NULL,
+		state TEXT NOT NULL,
+		rate TEXT NOT NULL,
+		balance TEXT NOT NULL,
+		unsettled TEXT NOT NULL,
+		withdrawn TEXT NOT NULL,
+		updated_height BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS payments_owner ON payments (owner, state)`,
+	`CREATE TABLE IF NOT EXISTS prices (
+		height BIGINT NOT NULL,
+		idx INTEGER NOT NULL,
+		source TEXT NOT NULL,
+		denom TEXT NOT NULL,
+		base_denom TEXT NOT NULL,
+		price TEXT NOT NULL,
+		timestamp TEXT NOT NULL,
+		PRIMARY KEY (height, idx)
+	)`,
+	`CREATE INDEX IF NOT EXISTS prices_denom ON prices (denom, base_denom, height)`,
+	`CREATE TABLE IF NOT EXISTS bme_ledger (
+		denom TEXT NOT NULL,
+		to_denom TEXT NOT NULL,
+		source TEXT NOT NULL,
+		height BIGINT NOT NULL,
+		sequence BIGINT NOT NULL,
+		burned_from TEXT NOT NULL,
+		minted_to TEXT NOT NULL,
+		burned TEXT NOT NULL,
+		burned_price TEXT NOT NULL,
+		minted TEXT NOT NULL,
+		minted_price TEXT NOT NULL,
+		executed_height BIGINT NOT NULL,
+		PRIMARY KEY (denom, to_denom, source, height, sequence)
+	)`,
+	`CREATE INDEX IF NOT EXISTS bme_ledger_source ON bme_ledger (source, executed_height)`,
+}
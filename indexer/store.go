@@ -0,0 +1,146 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	dv1 "pkg.akt.dev/go/node/deployment/v1"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	otypes "pkg.akt.dev/go/node/oracle/v2"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+func (s *store) init(ctx context.Context) error {
+	for _, stmt := range schema {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("indexer schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// write records block in single transaction. Writes are idempotent, so block replayed after restart
+// of the node is indexed again without errors.
+func (s *store) write(ctx context.Context, b *block) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	w := &txWriter{ctx: ctx, tx: tx, height: b.height}
+
+	w.exec(`INSERT INTO blocks (height, time) VALUES ($1, $2)
+		ON CONFLICT (height) DO UPDATE SET time = excluded.time`, b.height, b.time)
+
+	for _, ev := range b.events {
+		w.exec(`INSERT INTO events (height, idx, tx_index, type, attributes) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (height, idx) DO NOTHING`, b.height, ev.idx, ev.txIndex, ev.kind, ev.attributes)
+
+		if ev.msg != nil {
+			w.typed(ev.idx, ev.msg)
+		}
+	}
+
+	for _, p := range b.payments {
+		w.exec(`INSERT INTO payments (id, owner, state, rate, balance, unsettled, withdrawn, updated_height)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO UPDATE SET owner = excluded.owner, state = excluded.state, rate = excluded.rate,
+				balance = excluded.balance, unsettled = excluded.unsettled, withdrawn = excluded.withdrawn,
+				updated_height = excluded.updated_height`,
+			p.id, p.state.Owner, p.state.State.String(), p.state.Rate.String(), p.state.Balance.String(),
+			p.state.Unsettled.String(), p.state.Withdrawn.String(), b.height)
+	}
+
+	if w.err != nil {
+		err = w.err
+		return err
+	}
+
+	err = tx.Commit()
+
+	return err
+}
+
+// txWriter executes statements within transaction and keeps the first error
+type txWriter struct {
+	ctx    context.Context
+	tx     *sql.Tx
+	height int64
+	err    error
+}
+
+func (w *txWriter) exec(query string, args ...any) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = w.tx.ExecContext(w.ctx, query, args...)
+}
+
+func (w *txWriter) typed(idx int, msg proto.Message) {
+	switch ev := msg.(type) {
+	case *dv1.EventDeploymentCreated:
+		w.exec(`INSERT INTO deployments (owner, dseq, state, hash, created_height, updated_height)
+			VALUES ($1, $2, $3, $4, $5, $5)
+			ON CONFLICT (owner, dseq) DO UPDATE SET state = excluded.state, hash = excluded.hash,
+				updated_height = excluded.updated_height`,
+			ev.ID.Owner, int64(ev.ID.DSeq), dv1.DeploymentActive.String(), hex.EncodeToString(ev.Hash), w.height)
+	case *dv1.EventDeploymentUpdated:
+		w.exec(`UPDATE deployments SET hash = $3, updated_height = $4 WHERE owner = $1 AND dseq = $2`,
+			ev.ID.Owner, int64(ev.ID.DSeq), hex.EncodeToString(ev.Hash), w.height)
+	case *dv1.EventDeploymentClosed:
+		w.exec(`UPDATE deployments SET state = $3, updated_height = $4, closed_height = $4 WHERE owner = $1 AND dseq = $2`,
+			ev.ID.Owner, int64(ev.ID.DSeq), dv1.DeploymentClosed.String(), w.height)
+	case *mv1.EventLeaseCreated:
+		w.exec(`INSERT INTO leases (owner, dseq, gseq, oseq, provider, bseq, state, price_denom, price_amount, created_height)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (owner, dseq, gseq, oseq, provider, bseq) DO UPDATE SET state = excluded.state,
+				price_denom = excluded.price_denom, price_amount = excluded.price_amount`,
+			ev.ID.Owner, int64(ev.ID.DSeq), int64(ev.ID.GSeq), int64(ev.ID.OSeq), ev.ID.Provider, int64(ev.ID.BSeq),
+			mv1.LeaseActive.String(), ev.Price.Denom, ev.Price.Amount.String(), w.height)
+	case *mv1.EventLeaseClosed:
+		w.exec(`UPDATE leases SET state = $7, closed_height = $8, close_reason = $9
+			WHERE owner = $1 AND dseq = $2 AND gseq = $3 AND oseq = $4 AND provider = $5 AND bseq = $6`,
+			ev.ID.Owner, int64(ev.ID.DSeq), int64(ev.ID.GSeq), int64(ev.ID.OSeq), ev.ID.Provider, int64(ev.ID.BSeq),
+			mv1.LeaseClosed.String(), w.height, ev.Reason.String())
+	case *otypes.EventPriceData:
+		w.exec(`INSERT INTO prices (height, idx, source, denom, base_denom, price, timestamp)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (height, idx) DO NOTHING`,
+			w.height, idx, ev.Source, ev.Id.Denom, ev.Id.BaseDenom, ev.Price.String(), ev.Timestamp.UTC().Format(time.RFC3339Nano))
+	case *bmetypes.EventLedgerRecordExecuted:
+		burned, burnedPrice := coinPrice(ev.Burned)
+		minted, mintedPrice := coinPrice(ev.Minted)
+
+		w.exec(`INSERT INTO bme_ledger (denom, to_denom, source, height, sequence, burned_from, minted_to,
+				burned, burned_price, minted, minted_price, executed_height)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (denom, to_denom, source, height, sequence) DO NOTHING`,
+			ev.ID.Denom, ev.ID.ToDenom, ev.ID.Source, ev.ID.Height, ev.ID.Sequence, ev.BurnedFrom, ev.MintedTo,
+			burned, burnedPrice, minted, mintedPrice, w.height)
+	}
+}
+
+func coinPrice(cp *bmetypes.CoinPrice) (string, string) {
+	if cp == nil {
+		return "", ""
+	}
+
+	return cp.Coin.String(), cp.Price.String()
+}
@@ -23,7 +23,9 @@ type Publisher interface {
 // NOTE: this should probably be in util/event or something (not in provider/event)
 type Bus interface {
 	Publisher
-	Subscribe() (Subscriber, error)
+	Subscribe(...SubscribeOption) (Subscriber, error)
+	// Stats returns stats of all subscribers of the bus, including clones, grouped by subscriber name
+	Stats() []SubscriberStats
 	Close()
 	Done() <-chan struct{}
 }
@@ -32,34 +34,53 @@ type Bus interface {
 // A Clone() of a subscriber will emit all events that have not been emitted
 // from the cloned subscriber.  This is important so that events are not missed
 // when adding subscribers for sub-components (see `provider/bidengine/{service,order}.go`)
+//
+// Subscriber receives only events matching its filters. Events not consumed yet are buffered,
+// and the buffer is either unbounded or limited with the overflow policy, see WithBufferLimit.
 type Subscriber interface {
 	Events() <-chan Event
 	Clone() (Subscriber, error)
 	Close()
 	Done() <-chan struct{}
+	// Err returns the error subscriber has been closed with, e.g. ErrBufferOverflow, or nil
+	Err() error
 }
 
 type bus struct {
 	subscriptions map[*bus]bool
 
 	evbuf []Event
+	opts  subscribeOptions
+	stats *subscriberStats
+	reg   *registry
 
 	eventch  chan Event
 	parentch chan *bus
 
 	pubch   chan Event
-	subch   chan chan<- Subscriber
+	subch   chan subscribeRequest
 	unsubch chan *bus
 
 	lc lifecycle.Lifecycle
 }
 
+type subscribeRequest struct {
+	opts subscribeOptions
+	ch   chan<- subscribeResult
+}
+
+type subscribeResult struct {
+	sub *bus
+	err error
+}
+
 // NewBus runs a new bus and returns bus details
 func NewBus() Bus {
 	bus := &bus{
 		subscriptions: make(map[*bus]bool),
+		reg:           newRegistry(),
 		pubch:         make(chan Event),
-		subch:         make(chan chan<- Subscriber),
+		subch:         make(chan subscribeRequest),
 		unsubch:       make(chan *bus),
 		lc:            lifecycle.New(),
 	}
@@ -78,19 +99,32 @@ func (b *bus) Publish(ev Event) error {
 	}
 }
 
-func (b *bus) Subscribe() (Subscriber, error) {
-	ch := make(chan Subscriber, 1)
+func (b *bus) Subscribe(opts ...SubscribeOption) (Subscriber, error) {
+	return b.subscribe(newSubscribeOptions(opts))
+}
+
+func (b *bus) subscribe(opts subscribeOptions) (Subscriber, error) {
+	ch := make(chan subscribeResult, 1)
 
 	select {
-	case b.subch <- ch:
-		return <-ch, nil
+	case b.subch <- subscribeRequest{opts: opts, ch: ch}:
+		res := <-ch
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		return res.sub, nil
 	case <-b.lc.ShuttingDown():
 		return nil, ErrNotRunning
 	}
 }
 
 func (b *bus) Clone() (Subscriber, error) {
-	return b.Subscribe()
+	return b.subscribe(b.opts)
+}
+
+func (b *bus) Stats() []SubscriberStats {
+	return b.reg.stats()
 }
 
 func (b *bus) Events() <-chan Event {
@@ -105,6 +139,15 @@ func (b *bus) Done() <-chan struct{} {
 	return b.lc.Done()
 }
 
+func (b *bus) Err() error {
+	select {
+	case <-b.lc.ShuttingDown():
+		return b.lc.Error()
+	default:
+		return nil
+	}
+}
+
 func (b *bus) run() {
 	defer b.lc.ShutdownCompleted()
 
@@ -132,14 +175,22 @@ loop:
 
 		case outch <- curev:
 			// Event was emitted. Shrink current event buffer.
-			b.evbuf = b.evbuf[1:]
+			b.shift()
 
 		case ev := <-b.pubch:
 			// publish event
 
+			if !b.opts.match(ev) {
+				// events filtered out are not seen by clones either
+				continue
+			}
+
 			// Buffer event.
 			if b.eventch != nil {
-				b.evbuf = append(b.evbuf, ev)
+				if err := b.buffer(ev); err != nil {
+					b.lc.ShutdownInitiated(err)
+					break loop
+				}
 			}
 
 			// Publish to children.
@@ -149,13 +200,15 @@ loop:
 				}
 			}
 
-		case ch := <-b.subch:
+		case req := <-b.subch:
 			// new subscription
 
-			sub := newSubscriber(b)
-			b.subscriptions[sub] = true
+			sub, err := newSubscriber(b, req.opts)
+			if err == nil {
+				b.subscriptions[sub] = true
+			}
 
-			ch <- sub
+			req.ch <- subscribeResult{sub: sub, err: err}
 
 		case sub := <-b.unsubch:
 			// subscription closed
@@ -172,31 +225,75 @@ loop:
 		delete(b.subscriptions, sub)
 	}
 
+	if b.eventch != nil {
+		b.reg.remove(b)
+	}
+
 	if b.parentch != nil {
 		b.parentch <- b
 	}
 }
 
-func newSubscriber(parent *bus) *bus {
+// buffer appends event to the buffer applying overflow policy when the buffer is full
+func (b *bus) buffer(ev Event) error {
+	if b.opts.limit > 0 && len(b.evbuf) >= b.opts.limit {
+		switch b.opts.policy {
+		case DropNewest:
+			b.stats.dropped.Add(1)
+			return nil
+		case CloseOnOverflow:
+			return ErrBufferOverflow
+		default:
+			b.shift()
+			b.stats.dropped.Add(1)
+		}
+	}
+
+	b.evbuf = append(b.evbuf, ev)
+	b.stats.depth.Store(int64(len(b.evbuf)))
+
+	return nil
+}
+
+func (b *bus) shift() {
+	// release reference to the event, so it can be garbage collected while the buffer is still in use
+	b.evbuf[0] = nil
+	b.evbuf = b.evbuf[1:]
+	b.stats.depth.Store(int64(len(b.evbuf)))
+}
+
+func newSubscriber(parent *bus, opts subscribeOptions) (*bus, error) {
 	// Re-use bus struct, but populate output channel (eventch)
 	// to enable subscriber mode.
 
-	evbuf := make([]Event, len(parent.evbuf))
-	copy(evbuf, parent.evbuf)
-
 	sub := &bus{
 		eventch:  make(chan Event),
 		parentch: parent.unsubch,
-		evbuf:    evbuf,
+		opts:     opts,
+		stats:    &subscriberStats{},
+		reg:      parent.reg,
 
 		subscriptions: make(map[*bus]bool),
 		pubch:         make(chan Event),
-		subch:         make(chan chan<- Subscriber),
+		subch:         make(chan subscribeRequest),
 		unsubch:       make(chan *bus),
 		lc:            lifecycle.New(),
 	}
 
+	// events pending in the parent are subject to the filters and the buffer limit of the subscriber
+	for _, ev := range parent.evbuf {
+		if !opts.match(ev) {
+			continue
+		}
+
+		if err := sub.buffer(ev); err != nil {
+			return nil, err
+		}
+	}
+
+	parent.reg.add(sub)
+
 	go sub.run()
 
-	return sub
+	return sub, nil
 }
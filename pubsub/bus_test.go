@@ -147,3 +147,125 @@ type testEvent []byte
 func newEvent(addr []byte) testEvent {
 	return testEvent(addr)
 }
+
+type topicEvent string
+
+func (ev topicEvent) Topic() string {
+	return string(ev)
+}
+
+func TestSubscribeFilter(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe(pubsub.WithTopics("lease"))
+	require.NoError(t, err)
+
+	sub2, err := bus.Subscribe(pubsub.WithFilter(func(ev pubsub.Event) bool {
+		_, ok := ev.(testEvent)
+		return ok
+	}))
+	require.NoError(t, err)
+
+	ev := newEvent(ed25519.GenPrivKey().PubKey().Address())
+
+	assert.NoError(t, bus.Publish(topicEvent("order")))
+	assert.NoError(t, bus.Publish(ev))
+	assert.NoError(t, bus.Publish(topicEvent("lease")))
+
+	select {
+	case newEv := <-sub.Events():
+		assert.Equal(t, topicEvent("lease"), newEv)
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out")
+	}
+
+	select {
+	case newEv := <-sub2.Events():
+		assert.Equal(t, ev, newEv)
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out")
+	}
+
+	// clone inherits filter of the subscriber
+	clone, err := sub.Clone()
+	require.NoError(t, err)
+
+	assert.NoError(t, bus.Publish(topicEvent("order")))
+	assert.NoError(t, bus.Publish(topicEvent("lease")))
+
+	for _, s := range []pubsub.Subscriber{sub, clone} {
+		select {
+		case newEv := <-s.Events():
+			assert.Equal(t, topicEvent("lease"), newEv)
+		case <-pubsub.AfterThreadStart(t):
+			require.Fail(t, "time out")
+		}
+	}
+
+	select {
+	case <-sub.Events():
+		require.Fail(t, "spurious event")
+	case <-pubsub.AfterThreadStart(t):
+	}
+}
+
+func TestSubscribeBufferLimit(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	oldest, err := bus.Subscribe(pubsub.WithName("oldest"), pubsub.WithBufferLimit(2, pubsub.DropOldest))
+	require.NoError(t, err)
+
+	newest, err := bus.Subscribe(pubsub.WithName("newest"), pubsub.WithBufferLimit(2, pubsub.DropNewest))
+	require.NoError(t, err)
+
+	closing, err := bus.Subscribe(pubsub.WithName("close"), pubsub.WithBufferLimit(2, pubsub.CloseOnOverflow))
+	require.NoError(t, err)
+
+	for _, ev := range []topicEvent{"1", "2", "3"} {
+		assert.NoError(t, bus.Publish(ev))
+	}
+
+	select {
+	case <-closing.Done():
+		assert.ErrorIs(t, closing.Err(), pubsub.ErrBufferOverflow)
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out")
+	}
+
+	// allow event propagation
+	pubsub.SleepForThreadStart(t)
+
+	assert.Equal(t, []pubsub.SubscriberStats{
+		{Name: "close"},
+		{Name: "newest", Subscribers: 1, Depth: 2, Dropped: 1},
+		{Name: "oldest", Subscribers: 1, Depth: 2, Dropped: 1},
+	}, bus.Stats())
+
+	for sub, expected := range map[pubsub.Subscriber][]topicEvent{
+		oldest: {"2", "3"},
+		newest: {"1", "2"},
+	} {
+		for _, pev := range expected {
+			select {
+			case ev := <-sub.Events():
+				assert.Equal(t, pev, ev)
+			case <-pubsub.AfterThreadStart(t):
+				require.Fail(t, "time out")
+			}
+		}
+	}
+
+	oldest.Close()
+	assert.NoError(t, oldest.Err())
+
+	pubsub.SleepForThreadStart(t)
+
+	// dropped events of closed subscribers are kept
+	assert.Equal(t, []pubsub.SubscriberStats{
+		{Name: "close"},
+		{Name: "newest", Subscribers: 1, Dropped: 1},
+		{Name: "oldest", Dropped: 1},
+	}, bus.Stats())
+}
@@ -0,0 +1,50 @@
+package pubsub
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type collector struct {
+	bus         Bus
+	subscribers *prometheus.Desc
+	depth       *prometheus.Desc
+	dropped     *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*collector)(nil)
+
+// NewCollector returns prometheus collector of the bus subscriber stats labeled by subscriber name.
+// Name of the bus is added as constant label, so collectors of multiple buses can be registered.
+func NewCollector(name string, bus Bus) prometheus.Collector {
+	labels := prometheus.Labels{"bus": name}
+
+	return &collector{
+		bus: bus,
+		subscribers: prometheus.NewDesc(
+			"akash_pubsub_subscribers",
+			"Number of running subscribers",
+			[]string{"subscriber"}, labels),
+		depth: prometheus.NewDesc(
+			"akash_pubsub_subscriber_queue_depth",
+			"Number of events buffered by subscribers and not consumed yet",
+			[]string{"subscriber"}, labels),
+		dropped: prometheus.NewDesc(
+			"akash_pubsub_subscriber_dropped_events_total",
+			"Number of events discarded by subscriber overflow policy",
+			[]string{"subscriber"}, labels),
+	}
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.subscribers
+	ch <- c.depth
+	ch <- c.dropped
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, st := range c.bus.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.subscribers, prometheus.GaugeValue, float64(st.Subscribers), st.Name)
+		ch <- prometheus.MustNewConstMetric(c.depth, prometheus.GaugeValue, float64(st.Depth), st.Name)
+		ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(st.Dropped), st.Name)
+	}
+}
@@ -0,0 +1,59 @@
+package pubsub_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"pkg.akt.dev/node/v2/pubsub"
+)
+
+func TestCollector(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	_, err := bus.Subscribe(pubsub.WithName("leases"), pubsub.WithBufferLimit(1, pubsub.DropOldest))
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(topicEvent("1")))
+	require.NoError(t, bus.Publish(topicEvent("2")))
+
+	// allow event propagation
+	pubsub.SleepForThreadStart(t)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(pubsub.NewCollector("test", bus))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+
+	for _, family := range families {
+		require.Len(t, family.GetMetric(), 1)
+
+		metric := family.GetMetric()[0]
+		for _, label := range metric.GetLabel() {
+			switch label.GetName() {
+			case "bus":
+				require.Equal(t, "test", label.GetValue())
+			case "subscriber":
+				require.Equal(t, "leases", label.GetValue())
+			}
+		}
+
+		switch {
+		case metric.GetGauge() != nil:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		}
+	}
+
+	require.Equal(t, map[string]float64{
+		"akash_pubsub_subscribers":                     1,
+		"akash_pubsub_subscriber_queue_depth":          1,
+		"akash_pubsub_subscriber_dropped_events_total": 1,
+	}, values)
+}
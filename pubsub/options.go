@@ -0,0 +1,111 @@
+package pubsub
+
+import (
+	"errors"
+)
+
+// ErrBufferOverflow is the error subscriber with CloseOnOverflow policy is closed with
+// when its buffer is full
+var ErrBufferOverflow = errors.New("subscriber buffer overflow")
+
+// OverflowPolicy defines what subscriber does with new event when its buffer is full
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the oldest buffered event to make room for the new one
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the new event
+	DropNewest
+	// CloseOnOverflow closes the subscriber with ErrBufferOverflow
+	CloseOnOverflow
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop-oldest"
+	case DropNewest:
+		return "drop-newest"
+	case CloseOnOverflow:
+		return "close"
+	default:
+		return "unknown"
+	}
+}
+
+// TopicEvent is implemented by events published under a topic
+type TopicEvent interface {
+	Topic() string
+}
+
+// Filter reports whether subscriber receives the event
+type Filter func(Event) bool
+
+// SubscribeOption configures subscriber created by Subscribe.
+// Clones of the subscriber inherit its options.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	name    string
+	filters []Filter
+	limit   int
+	policy  OverflowPolicy
+}
+
+// WithName sets name subscriber is reported under in Stats
+func WithName(name string) SubscribeOption {
+	return func(opts *subscribeOptions) {
+		opts.name = name
+	}
+}
+
+// WithFilter delivers to the subscriber only events fn returns true for.
+// Multiple filters must all match.
+func WithFilter(fn Filter) SubscribeOption {
+	return func(opts *subscribeOptions) {
+		opts.filters = append(opts.filters, fn)
+	}
+}
+
+// WithTopics delivers to the subscriber only events implementing TopicEvent with one of given topics
+func WithTopics(topics ...string) SubscribeOption {
+	set := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		set[topic] = true
+	}
+
+	return WithFilter(func(ev Event) bool {
+		tev, ok := ev.(TopicEvent)
+		return ok && set[tev.Topic()]
+	})
+}
+
+// WithBufferLimit limits number of events buffered by the subscriber to limit,
+// policy defines what happens with events published while the buffer is full.
+// Zero or negative limit leaves the buffer unbounded, which is the default.
+func WithBufferLimit(limit int, policy OverflowPolicy) SubscribeOption {
+	return func(opts *subscribeOptions) {
+		opts.limit = limit
+		opts.policy = policy
+	}
+}
+
+func newSubscribeOptions(opts []SubscribeOption) subscribeOptions {
+	res := subscribeOptions{}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
+
+func (o subscribeOptions) match(ev Event) bool {
+	for _, fn := range o.filters {
+		if !fn(ev) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,95 @@
+package pubsub
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// SubscriberStats are stats of the subscribers sharing the same name
+type SubscriberStats struct {
+	Name string
+	// Subscribers is number of running subscribers
+	Subscribers int
+	// Depth is number of events buffered by running subscribers
+	Depth int
+	// Dropped is number of events discarded by overflow policy of all subscribers ever created under the name
+	Dropped uint64
+}
+
+// registry tracks subscribers of the bus and all its descendants
+type registry struct {
+	lock    sync.Mutex
+	subs    map[*bus]bool
+	dropped map[string]uint64
+}
+
+func newRegistry() *registry {
+	return &registry{
+		subs:    make(map[*bus]bool),
+		dropped: make(map[string]uint64),
+	}
+}
+
+func (r *registry) add(sub *bus) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.subs[sub] = true
+}
+
+// remove forgets closed subscriber, keeping its count of dropped events
+func (r *registry) remove(sub *bus) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.subs[sub] {
+		delete(r.subs, sub)
+		r.dropped[sub.opts.name] += sub.stats.dropped.Load()
+	}
+}
+
+func (r *registry) stats() []SubscriberStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	byName := make(map[string]*SubscriberStats)
+
+	get := func(name string) *SubscriberStats {
+		st, found := byName[name]
+		if !found {
+			st = &SubscriberStats{Name: name}
+			byName[name] = st
+		}
+
+		return st
+	}
+
+	for name, dropped := range r.dropped {
+		get(name).Dropped = dropped
+	}
+
+	for sub := range r.subs {
+		st := get(sub.opts.name)
+		st.Subscribers++
+		st.Depth += int(sub.stats.depth.Load())
+		st.Dropped += sub.stats.dropped.Load()
+	}
+
+	res := make([]SubscriberStats, 0, len(byName))
+	for _, st := range byName {
+		res = append(res, *st)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// subscriberStats are updated by the subscriber run loop and read by registry
+type subscriberStats struct {
+	depth   atomic.Int64
+	dropped atomic.Uint64
+}
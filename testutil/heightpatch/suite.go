@@ -0,0 +1,102 @@
+// Package heightpatch provides harness to test height patches against state of a running app.
+package heightpatch
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.akt.dev/node/v2/app"
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+)
+
+// Suite runs app up to the block preceding height of the patch,
+// so state can be prepared before the patch is applied and asserted after.
+type Suite struct {
+	t      testing.TB
+	app    *app.AkashApp
+	height int64
+	time   time.Time
+}
+
+// NewSuite returns Suite with app which has committed block at height-1
+func NewSuite(t testing.TB, height int64, opts ...app.SetupAppOption) *Suite {
+	require.Greater(t, height, int64(1), "height patch cannot be applied at genesis")
+
+	opts = append([]app.SetupAppOption{
+		app.WithHome(t.TempDir()),
+		app.WithGenesis(app.GenesisStateWithValSet),
+		app.WithInitialHeight(height - 1),
+	}, opts...)
+
+	s := &Suite{
+		t:      t,
+		app:    app.Setup(opts...),
+		height: height,
+		time:   time.Now().UTC(),
+	}
+
+	s.finalizeBlock(height - 1)
+
+	return s
+}
+
+func (s *Suite) App() *app.AkashApp {
+	return s.app
+}
+
+// Context returns context of the last committed block.
+// State written with it is committed along with the next block.
+func (s *Suite) Context() sdk.Context {
+	return s.app.NewUncachedContext(false, cmproto.Header{
+		ChainID: s.app.ChainID(),
+		Height:  s.app.LastBlockHeight(),
+		Time:    s.time,
+	})
+}
+
+// Apply commits block at the height of the patch. It fails the test
+// if no patch is registered at the height or patch has not been applied.
+func (s *Suite) Apply() *abci.ResponseFinalizeBlock {
+	patch, exists := utypes.GetHeightPatchesList()[s.height]
+	require.True(s.t, exists, "no height patch registered at height %d", s.height)
+
+	res := s.finalizeBlock(s.height)
+
+	applied := false
+	for _, ev := range res.Events {
+		if ev.Type != utypes.EventTypeHeightPatch {
+			continue
+		}
+
+		for _, attr := range ev.Attributes {
+			if attr.Key == utypes.AttributeKeyPatchName && attr.Value == patch.Name() {
+				applied = true
+			}
+		}
+	}
+
+	require.True(s.t, applied, "height patch %s has not been applied at height %d", patch.Name(), s.height)
+
+	return res
+}
+
+func (s *Suite) finalizeBlock(height int64) *abci.ResponseFinalizeBlock {
+	s.time = s.time.Add(6 * time.Second)
+
+	res, err := s.app.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height: height,
+		Time:   s.time,
+	})
+	require.NoError(s.t, err)
+
+	_, err = s.app.Commit()
+	require.NoError(s.t, err)
+
+	return res
+}
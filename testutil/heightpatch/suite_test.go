@@ -0,0 +1,63 @@
+package heightpatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+
+	"pkg.akt.dev/go/sdkutil"
+	"pkg.akt.dev/go/testutil"
+
+	apptypes "pkg.akt.dev/node/v2/app/types"
+	"pkg.akt.dev/node/v2/testutil/heightpatch"
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+)
+
+const testPatchHeight = 100
+
+type testPatch struct {
+	addr  sdk.AccAddress
+	coins sdk.Coins
+}
+
+var _ utypes.IHeightPatch = (*testPatch)(nil)
+
+func (p *testPatch) Name() string {
+	return "test-patch"
+}
+
+func (p *testPatch) Begin(ctx sdk.Context, keepers *apptypes.AppKeepers) {
+	if err := keepers.Cosmos.Bank.MintCoins(ctx, minttypes.ModuleName, p.coins); err != nil {
+		panic(err)
+	}
+
+	if err := keepers.Cosmos.Bank.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, p.addr, p.coins); err != nil {
+		panic(err)
+	}
+}
+
+func TestHeightPatch(t *testing.T) {
+	patch := &testPatch{
+		addr:  testutil.AccAddress(t),
+		coins: sdk.NewCoins(sdk.NewInt64Coin(sdkutil.DenomUakt, 1000)),
+	}
+
+	utypes.RegisterHeightPatch(testPatchHeight, patch)
+	t.Cleanup(func() {
+		utypes.UnregisterHeightPatch(testPatchHeight)
+	})
+
+	suite := heightpatch.NewSuite(t, testPatchHeight)
+	require.Equal(t, int64(testPatchHeight-1), suite.App().LastBlockHeight())
+
+	bkeeper := suite.App().Keepers.Cosmos.Bank
+	require.True(t, bkeeper.GetAllBalances(suite.Context(), patch.addr).IsZero())
+
+	suite.Apply()
+
+	require.Equal(t, int64(testPatchHeight), suite.App().LastBlockHeight())
+	require.Equal(t, patch.coins, bkeeper.GetAllBalances(suite.Context(), patch.addr))
+}
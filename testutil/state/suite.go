@@ -176,7 +176,7 @@ func SetupTestSuiteWithKeepers(t testing.TB, keepers Keepers) *TestSuite {
 	}
 
 	if keepers.Audit == nil {
-		keepers.Audit = akeeper.NewKeeper(cdc, app.GetKey(atypes.StoreKey))
+		keepers.Audit = akeeper.NewKeeper(cdc, app.GetKey(atypes.StoreKey), authtypes.NewModuleAddress(govtypes.ModuleName).String())
 	}
 
 	if keepers.Oracle == nil {
@@ -216,7 +216,7 @@ func SetupTestSuiteWithKeepers(t testing.TB, keepers Keepers) *TestSuite {
 		)
 	}
 	if keepers.Provider == nil {
-		keepers.Provider = pkeeper.NewKeeper(cdc, app.GetKey(ptypes.StoreKey))
+		keepers.Provider = pkeeper.NewKeeper(cdc, app.GetKey(ptypes.StoreKey), keepers.Bank, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 	}
 
 	hook := mhooks.New(keepers.Deployment, keepers.Market)
@@ -0,0 +1,5 @@
+// Package heightpatches is home of height patches, one time fixes of state applied at given height
+// without software upgrade proposal. No patches are scheduled at the moment. Each patch lives in its
+// own file and registers itself in init with utypes.RegisterHeightPatch, and is tested with
+// testutil/heightpatch harness.
+package heightpatches
@@ -0,0 +1,102 @@
+package v2_2_0
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmodule "github.com/cosmos/cosmos-sdk/types/module"
+
+	types "pkg.akt.dev/go/node/cert/v1"
+
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+	ckeeper "pkg.akt.dev/node/v2/x/cert/keeper"
+)
+
+type certMigrations struct {
+	utypes.Migrator
+}
+
+func newCertMigration(m utypes.Migrator) utypes.Migration {
+	return certMigrations{Migrator: m}
+}
+
+func (m certMigrations) GetHandler() sdkmodule.MigrationHandler {
+	return m.handler
+}
+
+// handler migrates cert from version 4 to 5.
+// Valid certificates are indexed by their expiry time. Revocation height of already
+// revoked certificates is unknown, they are indexed at sentinel height 0 so incremental
+// revocation queries do not report them as revoked at the upgrade height.
+func (m certMigrations) handler(sctx sdk.Context) error {
+	store := sctx.KVStore(m.StoreKey())
+
+	prefix := make([]byte, 0, len(ckeeper.CertPrefix)+len(ckeeper.CertStateValidPrefix))
+	prefix = append(prefix, ckeeper.CertPrefix...)
+	prefix = append(prefix, ckeeper.CertStateValidPrefix...)
+
+	iter := storetypes.KVStorePrefixIterator(store, prefix)
+
+	var keys [][]byte
+
+	for ; iter.Valid(); iter.Next() {
+		var cert types.Certificate
+		m.Codec().MustUnmarshal(iter.Value(), &cert)
+
+		_, id, err := ckeeper.ParseCertKey(iter.Key())
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		block, _ := pem.Decode(cert.Cert)
+		if block == nil {
+			_ = iter.Close()
+			return types.ErrInvalidCertificateValue
+		}
+
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		keys = append(keys, ckeeper.MustCertificateExpiryKey(crt.NotAfter, id))
+	}
+
+	_ = iter.Close()
+
+	for _, key := range keys {
+		store.Set(key, []byte{})
+	}
+
+	prefix = make([]byte, 0, len(ckeeper.CertPrefix)+len(ckeeper.CertStateRevokedPrefix))
+	prefix = append(prefix, ckeeper.CertPrefix...)
+	prefix = append(prefix, ckeeper.CertStateRevokedPrefix...)
+
+	iter = storetypes.KVStorePrefixIterator(store, prefix)
+
+	var revoked [][]byte
+
+	for ; iter.Valid(); iter.Next() {
+		_, id, err := ckeeper.ParseCertKey(iter.Key())
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		revoked = append(revoked, ckeeper.MustCertificateRevokedKey(0, id))
+	}
+
+	_ = iter.Close()
+
+	for _, key := range revoked {
+		store.Set(key, ckeeper.CertificateTimeValue(sctx.BlockTime()))
+	}
+
+	sctx.Logger().Info("indexed certificates", "module", types.ModuleName, "valid", len(keys), "revoked", len(revoked))
+
+	return nil
+}
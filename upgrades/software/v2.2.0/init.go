@@ -0,0 +1,17 @@
+// Package v2_2_0
+// nolint revive
+package v2_2_0
+
+import (
+	ctypes "pkg.akt.dev/go/node/cert/v1"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+)
+
+func init() {
+	utypes.RegisterUpgrade(UpgradeName, initUpgrade)
+
+	utypes.RegisterMigration(mv1.ModuleName, 9, newMarketMigration)
+	utypes.RegisterMigration(ctypes.ModuleName, 4, newCertMigration)
+}
@@ -0,0 +1,82 @@
+package v2_2_0
+
+import (
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmodule "github.com/cosmos/cosmos-sdk/types/module"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+	mkeeper "pkg.akt.dev/node/v2/x/market/keeper"
+	"pkg.akt.dev/node/v2/x/market/keeper/keys"
+)
+
+type marketMigrations struct {
+	utypes.Migrator
+}
+
+func newMarketMigration(m utypes.Migrator) utypes.Migration {
+	return marketMigrations{Migrator: m}
+}
+
+func (m marketMigrations) GetHandler() sdkmodule.MigrationHandler {
+	return m.handler
+}
+
+// handler migrates market from version 9 to 10.
+// Orders, bids and leases are re-saved to populate the (state, created at) and (state, price) indexes.
+func (m marketMigrations) handler(sctx sdk.Context) error {
+	skey := m.StoreKey().(*storetypes.KVStoreKey)
+	k := mkeeper.NewKeeper(m.Codec(), skey, nil, "").(*mkeeper.Keeper)
+
+	var orders []mvbeta.Order
+	err := k.Orders().Walk(sctx, nil, func(_ keys.OrderPrimaryKey, order mvbeta.Order) (bool, error) {
+		orders = append(orders, order)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if err := k.SaveOrder(sctx, order); err != nil {
+			return err
+		}
+	}
+
+	var bids []mvbeta.Bid
+	err = k.Bids().Walk(sctx, nil, func(_ keys.BidPrimaryKey, bid mvbeta.Bid) (bool, error) {
+		bids = append(bids, bid)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, bid := range bids {
+		if err := k.SaveBid(sctx, bid); err != nil {
+			return err
+		}
+	}
+
+	var leases []mv1.Lease
+	err = k.Leases().Walk(sctx, nil, func(_ keys.LeasePrimaryKey, lease mv1.Lease) (bool, error) {
+		leases = append(leases, lease)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, lease := range leases {
+		if err := k.SaveLease(sctx, lease); err != nil {
+			return err
+		}
+	}
+
+	sctx.Logger().Info("reindexed market store", "module", mv1.ModuleName, "orders", len(orders), "bids", len(bids), "leases", len(leases))
+
+	return nil
+}
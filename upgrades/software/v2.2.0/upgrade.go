@@ -0,0 +1,106 @@
+// Package v2_2_0
+// nolint revive
+package v2_2_0
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	atypes "pkg.akt.dev/go/node/audit/v1"
+	bmetypes "pkg.akt.dev/go/node/bme/v1"
+	ctypes "pkg.akt.dev/go/node/cert/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+
+	apptypes "pkg.akt.dev/node/v2/app/types"
+	utypes "pkg.akt.dev/node/v2/upgrades/types"
+)
+
+const (
+	UpgradeName = "v2.2.0"
+)
+
+type upgrade struct {
+	*apptypes.App
+	log log.Logger
+}
+
+var _ utypes.IUpgrade = (*upgrade)(nil)
+
+func initUpgrade(log log.Logger, app *apptypes.App) (utypes.IUpgrade, error) {
+	up := &upgrade{
+		App: app,
+		log: log.With("module", fmt.Sprintf("upgrade/%s", UpgradeName)),
+	}
+
+	return up, nil
+}
+
+func (up *upgrade) StoreLoader() *storetypes.StoreUpgrades {
+	return &storetypes.StoreUpgrades{
+		Added:   []string{},
+		Deleted: []string{},
+	}
+}
+
+func (up *upgrade) UpgradeHandler() upgradetypes.UpgradeHandler {
+	return func(ctx context.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		sctx := sdk.UnwrapSDKContext(ctx)
+
+		toVM, err := up.MM.RunMigrations(ctx, up.Configurator, fromVM)
+		if err != nil {
+			return toVM, err
+		}
+
+		// Set default bid and order expiry, lease migration and SLA params for market module
+		mparams, err := up.Keepers.Akash.Market.GetParams(sctx)
+		if err != nil {
+			return toVM, fmt.Errorf("failed to get market params: %w", err)
+		}
+
+		mparams.BidMaxAge = mvbeta.DefaultBidMaxAge
+		mparams.OrderMaxAge = mvbeta.DefaultOrderMaxAge
+		mparams.MaxLeaseMigrationOverlap = mvbeta.DefaultMaxLeaseMigrationOverlap
+		mparams.SLAEpochBlocks = mvbeta.DefaultSLAEpochBlocks
+
+		if err = up.Keepers.Akash.Market.SetParams(sctx, mparams); err != nil {
+			return toVM, fmt.Errorf("failed to set market params: %w", err)
+		}
+
+		// Provider module had no params before, bond is not required and slashing is off by default
+		if err = up.Keepers.Akash.Provider.SetParams(sctx, ptypes.DefaultParams()); err != nil {
+			return toVM, fmt.Errorf("failed to set provider params: %w", err)
+		}
+
+		// Cert module had no params before, set default certificate expiry epoch
+		if err = up.Keepers.Akash.Cert.SetParams(sctx, ctypes.DefaultParams()); err != nil {
+			return toVM, fmt.Errorf("failed to set cert params: %w", err)
+		}
+
+		// Audit module had no params before, existing attestations have no expiry
+		if err = up.Keepers.Akash.Audit.SetParams(sctx, atypes.DefaultParams()); err != nil {
+			return toVM, fmt.Errorf("failed to set audit params: %w", err)
+		}
+
+		// ACT fees are not valued in AKT until governance sets the per block cap
+		bparams, err := up.Keepers.Akash.Bme.GetParams(sctx)
+		if err != nil {
+			return toVM, fmt.Errorf("failed to get bme params: %w", err)
+		}
+
+		bparams.FeePremiumBps = bmetypes.DefaultFeePremiumBps
+		bparams.MaxBlockActFees = sdkmath.ZeroInt()
+
+		if err = up.Keepers.Akash.Bme.SetParams(sctx, bparams); err != nil {
+			return toVM, fmt.Errorf("failed to set bme params: %w", err)
+		}
+
+		return toVM, nil
+	}
+}
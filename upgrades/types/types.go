@@ -13,6 +13,14 @@ import (
 	apptypes "pkg.akt.dev/node/v2/app/types"
 )
 
+const (
+	// EventTypeHeightPatch is emitted when height patch is applied
+	EventTypeHeightPatch = "height_patch"
+
+	AttributeKeyPatchName   = "name"
+	AttributeKeyPatchHeight = "height"
+)
+
 var (
 	upgrades      = map[string]UpgradeInitFn{}
 	heightPatches = map[int64]IHeightPatch{}
@@ -92,6 +100,12 @@ func RegisterHeightPatch(height int64, patch IHeightPatch) {
 	heightPatches[height] = patch
 }
 
+// UnregisterHeightPatch removes patch registered for the height.
+// It is meant for tests registering patches of their own.
+func UnregisterHeightPatch(height int64) {
+	delete(heightPatches, height)
+}
+
 func GetUpgradesList() map[string]UpgradeInitFn {
 	return upgrades
 }
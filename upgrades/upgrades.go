@@ -1,6 +1,10 @@
 package upgrades
 
 import (
+	// nolint: revive
+	_ "pkg.akt.dev/node/v2/upgrades/heightpatches"
 	// nolint: revive
 	_ "pkg.akt.dev/node/v2/upgrades/software/v2.1.0"
+	// nolint: revive
+	_ "pkg.akt.dev/node/v2/upgrades/software/v2.2.0"
 )
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Namespace = "akash"
+
+// EndBlockerProcessed counts records processed by module EndBlockers, labeled by module and kind of the processing,
+// e.g. market/bids_expired or bme/records_executed
+var EndBlockerProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Name:      "endblocker_processed_total",
+	Help:      "Number of records processed by module EndBlockers",
+}, []string{"module", "kind"})
+
+// AddEndBlockerProcessed adds count of records of given kind processed by EndBlocker of the module
+func AddEndBlockerProcessed(module, kind string, count int) {
+	if count > 0 {
+		EndBlockerProcessed.WithLabelValues(module, kind).Add(float64(count))
+	}
+}
+
+// Register registers module metrics along with given collectors.
+// Collectors registered already, e.g. by another app instance in the same process, are skipped,
+// so given collectors must be process wide rather than bound to an app instance.
+func Register(reg prometheus.Registerer, collectors ...prometheus.Collector) error {
+	for _, collector := range append([]prometheus.Collector{EndBlockerProcessed}, collectors...) {
+		if err := reg.Register(collector); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if !errors.As(err, &are) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
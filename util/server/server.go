@@ -1,11 +1,14 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 
+	"cosmossdk.io/log"
 	tmcmd "github.com/cometbft/cometbft/cmd/cometbft/commands"
 	tmjson "github.com/cometbft/cometbft/libs/json"
 	tmtypes "github.com/cometbft/cometbft/types"
@@ -17,6 +20,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	sdkserver "github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -25,10 +29,13 @@ import (
 const (
 	// Tendermint full-node start flags
 	flagTraceStore = "trace-store"
+
+	flagExportModules = "modules"
+	flagExportFilter  = "filter"
 )
 
 // Commands server commands
-func Commands(defaultNodeHome string, appCreator servertypes.AppCreator, appExport servertypes.AppExporter, addStartFlags servertypes.ModuleInitFlags) []*cobra.Command {
+func Commands(defaultNodeHome string, appCreator servertypes.AppCreator, appExport AppStreamExporter, addStartFlags servertypes.ModuleInitFlags) []*cobra.Command {
 	tendermintCmd := &cobra.Command{
 		Use:   "tendermint",
 		Short: "Tendermint subcommands",
@@ -57,11 +64,32 @@ func Commands(defaultNodeHome string, appCreator servertypes.AppCreator, appExpo
 	return cmds
 }
 
-// ExportCmd dumps app state to JSON.
-func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cobra.Command {
+// AppStreamExporter exports application state of the modules. AppState of the returned ExportedApp is left empty,
+// app state is written by the returned function instead, so it does not have to be held in memory.
+type AppStreamExporter func(
+	logger log.Logger,
+	db dbm.DB,
+	traceWriter io.Writer,
+	height int64,
+	forZeroHeight bool,
+	jailAllowedAddrs []string,
+	appOpts servertypes.AppOptions,
+	modulesToExport []string,
+	filters []string,
+) (servertypes.ExportedApp, func(io.Writer) error, error)
+
+// ExportCmd streams app state to JSON.
+func ExportCmd(appExporter AppStreamExporter, defaultNodeHome string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export state to JSON",
+		Long: `Export state to JSON.
+State is written to the output as it is exported, one module at a time.
+Export can be limited to the given modules and deployment and market records can be filtered in form of module.field=value,
+where field is one of owner, provider (market only) or state.
+Example:
+	akash export --modules deployment,market --filter market.owner=akash1... --filter market.state=active
+`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			sctx := sdkserver.GetServerContextFromCmd(cmd)
 			config := sctx.Config
@@ -79,9 +107,8 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 			}
 
 			outFile := os.Stdout
-			var outputDocument string
 
-			if outputDocument, _ = cmd.Flags().GetString(cflags.FlagOutputDocument); outputDocument != "-" {
+			if outputDocument, _ := cmd.Flags().GetString(cflags.FlagOutputDocument); outputDocument != "-" {
 				outFile, err = os.Create(outputDocument) //nolint: gosec
 				if err != nil {
 					return err
@@ -122,8 +149,9 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 			forZeroHeight, _ := cmd.Flags().GetBool(cflags.FlagForZeroHeight)
 			jailAllowedAddrs, _ := cmd.Flags().GetStringSlice(cflags.FlagJailAllowedAddrs)
 			modulesToExport, _ := cmd.Flags().GetStringSlice(cflags.FlagModulesToExport)
+			filters, _ := cmd.Flags().GetStringSlice(flagExportFilter)
 
-			exported, err := appExporter(
+			exported, writeAppState, err := appExporter(
 				sctx.Logger,
 				db,
 				traceWriter,
@@ -132,6 +160,7 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 				jailAllowedAddrs,
 				sctx.Viper,
 				modulesToExport,
+				filters,
 			)
 
 			if err != nil {
@@ -143,7 +172,7 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 				return err
 			}
 
-			doc.AppState = exported.AppState
+			doc.AppState = nil
 			doc.Validators = exported.Validators
 			doc.InitialHeight = exported.Height
 			doc.ConsensusParams = &tmtypes.ConsensusParams{
@@ -164,30 +193,34 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 			// NOTE: Tendermint uses a custom JSON decoder for GenesisDoc
 			// (except for stuff inside AppState). Inside AppState, we're free
 			// to encode as protobuf or amino.
+			// app_state is omitted from the encoded document and is appended
+			// as the last field while it is being exported.
 			encoded, err := tmjson.Marshal(doc)
 			if err != nil {
 				return err
 			}
 
-			out := sdk.MustSortJSON(encoded)
-			if outputDocument == "-" {
-				cmd.Println(string(out))
-				_, err = fmt.Fprintln(outFile, string(out))
-				if err != nil {
-					return err
-				}
-				return nil
+			encoded = bytes.TrimSuffix(sdk.MustSortJSON(encoded), []byte("}"))
+
+			out := bufio.NewWriter(outFile)
+
+			if _, err = out.Write(encoded); err != nil {
+				return err
 			}
 
-			var exportedGenDoc tmtypes.GenesisDoc
-			if err = tmjson.Unmarshal(out, &exportedGenDoc); err != nil {
+			if _, err = out.WriteString(`,"app_state":`); err != nil {
 				return err
 			}
-			if err = exportedGenDoc.SaveAs(outputDocument); err != nil {
+
+			if err = writeAppState(out); err != nil {
+				return fmt.Errorf("error exporting state: %v", err)
+			}
+
+			if _, err = out.WriteString("}\n"); err != nil {
 				return err
 			}
 
-			return nil
+			return out.Flush()
 		},
 	}
 
@@ -196,8 +229,18 @@ func ExportCmd(appExporter servertypes.AppExporter, defaultNodeHome string) *cob
 	cmd.Flags().Bool(cflags.FlagForZeroHeight, false, "Export state to start at height zero (perform preprocessing)")
 	cmd.Flags().StringSlice(cflags.FlagJailAllowedAddrs, []string{}, "Comma-separated list of operator addresses of jailed validators to unjail")
 	cmd.Flags().StringSlice(cflags.FlagModulesToExport, []string{}, "Comma-separated list of modules to export. If empty, will export all modules")
+	cmd.Flags().StringSlice(flagExportFilter, []string{}, "Filter of exported records in form of module.field=value, may be repeated")
 	cmd.Flags().String(cflags.FlagOutputDocument, "-", "Exported state is written to the given file instead of STDOUT")
 
+	// --modules is accepted as a short form of --modules-to-export
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == flagExportModules {
+			name = cflags.FlagModulesToExport
+		}
+
+		return pflag.NormalizedName(name)
+	})
+
 	return cmd
 }
 
@@ -0,0 +1,80 @@
+package statediff
+
+import (
+	"bytes"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Kind is the kind of change of a key between two versions of a store
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change describes a key which differs between two versions of a store.
+// From is nil for added keys and To is nil for removed keys.
+type Change struct {
+	Kind Kind
+	Key  []byte
+	From []byte
+	To   []byte
+}
+
+// Diff walks both stores in ascending key order and calls fn for every key which was added, removed or changed
+// in the to store. Changes are reported in key order, so the output is deterministic.
+func Diff(from, to storetypes.KVStore, fn func(Change) error) error {
+	fromIter := from.Iterator(nil, nil)
+	defer func() {
+		_ = fromIter.Close()
+	}()
+
+	toIter := to.Iterator(nil, nil)
+	defer func() {
+		_ = toIter.Close()
+	}()
+
+	for fromIter.Valid() || toIter.Valid() {
+		var change Change
+
+		switch {
+		case !toIter.Valid():
+			change = Change{Kind: Removed, Key: fromIter.Key(), From: fromIter.Value()}
+			fromIter.Next()
+		case !fromIter.Valid():
+			change = Change{Kind: Added, Key: toIter.Key(), To: toIter.Value()}
+			toIter.Next()
+		default:
+			cmp := bytes.Compare(fromIter.Key(), toIter.Key())
+
+			switch {
+			case cmp < 0:
+				change = Change{Kind: Removed, Key: fromIter.Key(), From: fromIter.Value()}
+				fromIter.Next()
+			case cmp > 0:
+				change = Change{Kind: Added, Key: toIter.Key(), To: toIter.Value()}
+				toIter.Next()
+			default:
+				if !bytes.Equal(fromIter.Value(), toIter.Value()) {
+					change = Change{Kind: Changed, Key: fromIter.Key(), From: fromIter.Value(), To: toIter.Value()}
+				}
+
+				fromIter.Next()
+				toIter.Next()
+			}
+		}
+
+		if change.Kind == "" {
+			continue
+		}
+
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package statediff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/dbadapter"
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+func TestDiff(t *testing.T) {
+	from := dbadapter.Store{DB: dbm.NewMemDB()}
+	to := dbadapter.Store{DB: dbm.NewMemDB()}
+
+	from.Set([]byte("a"), []byte("1"))
+	from.Set([]byte("b"), []byte("2"))
+	from.Set([]byte("d"), []byte("4"))
+
+	to.Set([]byte("b"), []byte("2"))
+	to.Set([]byte("c"), []byte("3"))
+	to.Set([]byte("d"), []byte("5"))
+	to.Set([]byte("e"), []byte("6"))
+
+	var changes []Change
+	err := Diff(from, to, func(change Change) error {
+		changes = append(changes, change)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []Change{
+		{Kind: Removed, Key: []byte("a"), From: []byte("1")},
+		{Kind: Added, Key: []byte("c"), To: []byte("3")},
+		{Kind: Changed, Key: []byte("d"), From: []byte("4"), To: []byte("5")},
+		{Kind: Added, Key: []byte("e"), To: []byte("6")},
+	}, changes)
+}
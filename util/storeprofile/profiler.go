@@ -0,0 +1,82 @@
+// Package storeprofile attributes gas and time spent by state machine code to the stores it accesses.
+package storeprofile
+
+import (
+	"sync"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Stats are gas consumed by accesses of a store and time attributed to them
+type Stats struct {
+	Gas      storetypes.Gas
+	Duration time.Duration
+}
+
+// Profiler collects Stats of stores accessed via multistore wrapped by it.
+// Time elapsed since the previous access of any store is attributed to the store accessed,
+// so time spent by module code between accesses counts towards the store module accesses next.
+type Profiler struct {
+	lock   sync.Mutex
+	last   time.Time
+	meters map[string]storetypes.GasMeter
+	times  map[string]time.Duration
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		last:   time.Now(),
+		meters: make(map[string]storetypes.GasMeter),
+		times:  make(map[string]time.Duration),
+	}
+}
+
+// CacheMultiStore returns ms which accounts all accesses of its stores,
+// including stores of the multistores cached from it
+func (p *Profiler) CacheMultiStore(ms storetypes.CacheMultiStore) storetypes.CacheMultiStore {
+	return &cacheMultiStore{
+		MultiStore: ms,
+		parent:     ms,
+		p:          p,
+	}
+}
+
+// Stats returns stats keyed by store name
+func (p *Profiler) Stats() map[string]Stats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	res := make(map[string]Stats, len(p.meters))
+
+	for name, meter := range p.meters {
+		res[name] = Stats{
+			Gas:      meter.GasConsumed(),
+			Duration: p.times[name],
+		}
+	}
+
+	return res
+}
+
+func (p *Profiler) meter(name string) storetypes.GasMeter {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	meter, found := p.meters[name]
+	if !found {
+		meter = storetypes.NewInfiniteGasMeter()
+		p.meters[name] = meter
+	}
+
+	return meter
+}
+
+func (p *Profiler) touch(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	p.times[name] += now.Sub(p.last)
+	p.last = now
+}
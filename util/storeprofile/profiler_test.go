@@ -0,0 +1,40 @@
+package storeprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+func TestProfiler(t *testing.T) {
+	keyA := storetypes.NewKVStoreKey("a")
+	keyB := storetypes.NewKVStoreKey("b")
+
+	rs := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	rs.MountStoreWithDB(keyA, storetypes.StoreTypeIAVL, nil)
+	rs.MountStoreWithDB(keyB, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, rs.LoadLatestVersion())
+
+	p := NewProfiler()
+	ms := p.CacheMultiStore(rs.CacheMultiStore())
+
+	ms.GetKVStore(keyA).Set([]byte("key"), []byte("value"))
+	require.Equal(t, []byte("value"), ms.GetKVStore(keyA).Get([]byte("key")))
+
+	// accesses of cached multistore are accounted as well
+	cms := ms.CacheMultiStore()
+	require.False(t, cms.GetKVStore(keyB).Has([]byte("key")))
+
+	stats := p.Stats()
+	require.Len(t, stats, 2)
+
+	cfg := storetypes.KVGasConfig()
+	require.Equal(t, cfg.WriteCostFlat+cfg.WriteCostPerByte*8+cfg.ReadCostFlat+cfg.ReadCostPerByte*8, stats["a"].Gas)
+	require.Equal(t, cfg.HasCost, stats["b"].Gas)
+}
@@ -0,0 +1,85 @@
+package storeprofile
+
+import (
+	"cosmossdk.io/store/gaskv"
+	storetypes "cosmossdk.io/store/types"
+)
+
+type cacheMultiStore struct {
+	storetypes.MultiStore
+	parent storetypes.CacheMultiStore
+	p      *Profiler
+}
+
+var _ storetypes.CacheMultiStore = (*cacheMultiStore)(nil)
+
+func (ms *cacheMultiStore) GetKVStore(key storetypes.StoreKey) storetypes.KVStore {
+	name := key.Name()
+
+	return &kvStore{
+		KVStore: gaskv.NewStore(ms.parent.GetKVStore(key), ms.p.meter(name), storetypes.KVGasConfig()),
+		name:    name,
+		p:       ms.p,
+	}
+}
+
+func (ms *cacheMultiStore) CacheMultiStore() storetypes.CacheMultiStore {
+	return ms.p.CacheMultiStore(ms.parent.CacheMultiStore())
+}
+
+func (ms *cacheMultiStore) CacheWrap() storetypes.CacheWrap {
+	return ms.CacheMultiStore()
+}
+
+func (ms *cacheMultiStore) Write() {
+	ms.parent.Write()
+}
+
+type kvStore struct {
+	storetypes.KVStore
+	name string
+	p    *Profiler
+}
+
+var _ storetypes.KVStore = (*kvStore)(nil)
+
+func (s *kvStore) Get(key []byte) []byte {
+	s.p.touch(s.name)
+	return s.KVStore.Get(key)
+}
+
+func (s *kvStore) Has(key []byte) bool {
+	s.p.touch(s.name)
+	return s.KVStore.Has(key)
+}
+
+func (s *kvStore) Set(key, value []byte) {
+	s.p.touch(s.name)
+	s.KVStore.Set(key, value)
+}
+
+func (s *kvStore) Delete(key []byte) {
+	s.p.touch(s.name)
+	s.KVStore.Delete(key)
+}
+
+func (s *kvStore) Iterator(start, end []byte) storetypes.Iterator {
+	s.p.touch(s.name)
+	return &iterator{Iterator: s.KVStore.Iterator(start, end), name: s.name, p: s.p}
+}
+
+func (s *kvStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	s.p.touch(s.name)
+	return &iterator{Iterator: s.KVStore.ReverseIterator(start, end), name: s.name, p: s.p}
+}
+
+type iterator struct {
+	storetypes.Iterator
+	name string
+	p    *Profiler
+}
+
+func (it *iterator) Next() {
+	it.p.touch(it.name)
+	it.Iterator.Next()
+}
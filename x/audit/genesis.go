@@ -29,11 +29,27 @@ func ValidateGenesis(data *types.GenesisState) error {
 		}
 	}
 
-	return nil
+	for _, auditor := range data.Auditors {
+		if err := auditor.Validate(); err != nil {
+			return errorsmod.Wrap(err, "auditor registry: invalid auditor")
+		}
+	}
+
+	return data.Params.Validate()
 }
 
 // InitGenesis initiate genesis state and return updated validator details
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *types.GenesisState) {
+	if err := keeper.SetParams(ctx, data.Params); err != nil {
+		panic(errorsmod.Wrap(err, "unable to init genesis with params"))
+	}
+
+	for _, auditor := range data.Auditors {
+		if err := keeper.SetAuditor(ctx, auditor); err != nil {
+			panic(errorsmod.Wrap(err, "unable to init genesis with auditor"))
+		}
+	}
+
 	for _, record := range data.Providers {
 		owner, err := sdk.AccAddressFromBech32(record.Owner)
 
@@ -49,7 +65,7 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *types.GenesisState
 		err = keeper.CreateOrUpdateProviderAttributes(ctx, types.ProviderID{
 			Owner:   owner,
 			Auditor: auditor,
-		}, record.Attributes)
+		}, record.Attributes, record.ExpiresAt, record.EvidenceHash)
 		if err != nil {
 			panic(errorsmod.Wrap(err, "unable to init genesis with provider"))
 		}
@@ -61,23 +77,41 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	var records []types.AuditedProvider
 
 	k.WithProviders(ctx, func(provider types.AuditedProvider) bool {
+		// expired attestations which have not been pruned yet are not exported
+		if provider.ExpiresAt != nil && !ctx.BlockTime().Before(*provider.ExpiresAt) {
+			return false
+		}
+
 		records = append(records, types.AuditedProvider{
-			Owner:      provider.Owner,
-			Auditor:    provider.Auditor,
-			Attributes: provider.Attributes.Dup(),
+			Owner:        provider.Owner,
+			Auditor:      provider.Auditor,
+			Attributes:   provider.Attributes.Dup(),
+			ExpiresAt:    provider.ExpiresAt,
+			EvidenceHash: provider.EvidenceHash,
 		})
 		return false
 	})
 
+	var auditors []types.Auditor
+
+	k.WithAuditors(ctx, func(auditor types.Auditor) bool {
+		auditors = append(auditors, auditor)
+		return false
+	})
+
 	return &types.GenesisState{
 		Providers: records,
+		Auditors:  auditors,
+		Params:    k.GetParams(ctx),
 	}
 }
 
 // DefaultGenesisState returns default genesis state as raw bytes for the provider
 // module.
 func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{}
+	return &types.GenesisState{
+		Params: types.DefaultParams(),
+	}
 }
 
 // GetGenesisStateFromAppState returns x/audit GenesisState given raw application
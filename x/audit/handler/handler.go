@@ -24,6 +24,15 @@ func NewHandler(keeper keeper.Keeper) baseapp.MsgServiceHandler {
 		case *types.MsgDeleteProviderAttributes:
 			res, err := ms.DeleteProviderAttributes(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUpdateParams:
+			res, err := ms.UpdateParams(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRegisterAuditor:
+			res, err := ms.RegisterAuditor(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAuditorState:
+			res, err := ms.SetAuditorState(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		}
 
 		return nil, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized message type: %T", msg)
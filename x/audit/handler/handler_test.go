@@ -18,8 +18,11 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	testutilmod "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/audit/v1"
+	attrv1 "pkg.akt.dev/go/node/types/attributes/v1"
 
 	"pkg.akt.dev/go/testutil"
 
@@ -54,7 +57,7 @@ func setupTestSuite(t *testing.T) *testSuite {
 
 	suite.ctx = sdk.NewContext(suite.ms, tmproto.Header{}, true, testutil.Logger(t))
 
-	suite.keeper = keeper.NewKeeper(cdc, aKey)
+	suite.keeper = keeper.NewKeeper(cdc, aKey, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 
 	suite.handler = handler.NewHandler(suite.keeper)
 
@@ -73,7 +76,7 @@ func TestProviderSignNew(t *testing.T) {
 	suite := setupTestSuite(t)
 
 	owner := testutil.AccAddress(t)
-	auditor := testutil.AccAddress(t)
+	auditor := suite.registerAuditor("*")
 
 	msg := &types.MsgSignProviderAttributes{
 		Owner:      owner.String(),
@@ -94,7 +97,7 @@ func TestProviderSignAndUpdate(t *testing.T) {
 	suite := setupTestSuite(t)
 
 	owner := testutil.AccAddress(t)
-	auditor := testutil.AccAddress(t)
+	auditor := suite.registerAuditor("*")
 	originAttr := testutil.Attributes(t)
 
 	msg := &types.MsgSignProviderAttributes{
@@ -139,7 +142,7 @@ func TestProviderDeleteFull(t *testing.T) {
 
 	msg := &types.MsgSignProviderAttributes{
 		Owner:      testutil.AccAddress(t).String(),
-		Auditor:    testutil.AccAddress(t).String(),
+		Auditor:    suite.registerAuditor("*").String(),
 		Attributes: testutil.Attributes(t),
 	}
 
@@ -171,7 +174,7 @@ func TestProviderDeleteAttribute(t *testing.T) {
 
 	msg := &types.MsgSignProviderAttributes{
 		Owner:      owner.String(),
-		Auditor:    testutil.AccAddress(t).String(),
+		Auditor:    suite.registerAuditor("*").String(),
 		Attributes: testutil.Attributes(t),
 	}
 
@@ -200,6 +203,96 @@ func TestProviderDeleteAttribute(t *testing.T) {
 	require.Equal(t, prov, msgSignProviderAttributesToResponse(msg))
 }
 
+func TestProviderSignUnregisteredAuditor(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	msg := &types.MsgSignProviderAttributes{
+		Owner:      testutil.AccAddress(t).String(),
+		Auditor:    testutil.AccAddress(t).String(),
+		Attributes: testutil.Attributes(t),
+	}
+
+	res, err := suite.handler(suite.ctx, msg)
+	require.Nil(t, res)
+	require.ErrorIs(t, err, types.ErrAuditorNotRegistered)
+}
+
+func TestProviderSignOutOfScope(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	auditor := suite.registerAuditor("region", "capabilities/gpu/*")
+
+	msg := &types.MsgSignProviderAttributes{
+		Owner:   testutil.AccAddress(t).String(),
+		Auditor: auditor.String(),
+		Attributes: attrv1.Attributes{
+			{Key: "region", Value: "us-west"},
+			{Key: "capabilities/gpu/vendor/nvidia/model/a100", Value: "true"},
+		},
+	}
+
+	res, err := suite.handler(suite.ctx, msg)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	msg.Attributes = append(msg.Attributes, attrv1.Attribute{Key: "tier", Value: "community"})
+
+	res, err = suite.handler(suite.ctx, msg)
+	require.Nil(t, res)
+	require.ErrorIs(t, err, types.ErrAttributeOutOfScope)
+}
+
+func TestProviderSignSuspendedAuditor(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	auditor := suite.registerAuditor("*")
+
+	res, err := suite.handler(suite.ctx, &types.MsgSetAuditorState{
+		Authority: testutil.AccAddress(t).String(),
+		Address:   auditor.String(),
+		State:     types.AuditorSuspended,
+	})
+	require.Nil(t, res)
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+
+	res, err = suite.handler(suite.ctx, &types.MsgSetAuditorState{
+		Authority: suite.keeper.GetAuthority(),
+		Address:   auditor.String(),
+		State:     types.AuditorSuspended,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	res, err = suite.handler(suite.ctx, &types.MsgSignProviderAttributes{
+		Owner:      testutil.AccAddress(t).String(),
+		Auditor:    auditor.String(),
+		Attributes: testutil.Attributes(t),
+	})
+	require.Nil(t, res)
+	require.ErrorIs(t, err, types.ErrAuditorSuspended)
+}
+
+func (st *testSuite) registerAuditor(scopes ...string) sdk.AccAddress {
+	st.t.Helper()
+
+	addr := testutil.AccAddress(st.t)
+
+	res, err := st.handler(st.ctx, &types.MsgRegisterAuditor{
+		Authority: st.keeper.GetAuthority(),
+		Auditor: types.Auditor{
+			Address: addr.String(),
+			Name:    "auditor",
+			Website: "https://auditor.example.com",
+			Scopes:  scopes,
+			State:   types.AuditorActive,
+		},
+	})
+	require.NoError(st.t, err)
+	require.NotNil(st.t, res)
+
+	return addr
+}
+
 func msgSignProviderAttributesToResponse(msg *types.MsgSignProviderAttributes) types.AuditedProviders {
 	// create handler sorts attributes, so do we to ensure same order
 
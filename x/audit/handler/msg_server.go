@@ -4,6 +4,7 @@ import (
 	"context"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/audit/v1"
 
@@ -41,7 +42,11 @@ func (ms msgServer) SignProviderAttributes(goCtx context.Context, msg *types.Msg
 		Auditor: auditor,
 	}
 
-	if err = ms.keeper.CreateOrUpdateProviderAttributes(ctx, provID, msg.Attributes); err != nil {
+	if err = ms.keeper.ValidateAuditorScope(ctx, auditor, msg.Attributes); err != nil {
+		return nil, err
+	}
+
+	if err = ms.keeper.CreateOrUpdateProviderAttributes(ctx, provID, msg.Attributes, msg.ExpiresAt, msg.EvidenceHash); err != nil {
 		return nil, err
 	}
 
@@ -73,3 +78,49 @@ func (ms msgServer) DeleteProviderAttributes(goCtx context.Context, msg *types.M
 
 	return &types.MsgDeleteProviderAttributesResponse{}, nil
 }
+
+func (ms msgServer) UpdateParams(goCtx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if ms.keeper.GetAuthority() != req.Authority {
+		return nil, govtypes.ErrInvalidSigner.Wrapf("invalid authority; expected %s, got %s", ms.keeper.GetAuthority(), req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := ms.keeper.SetParams(ctx, req.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+// RegisterAuditor defines a method that adds auditor to the registry or updates its record
+func (ms msgServer) RegisterAuditor(goCtx context.Context, req *types.MsgRegisterAuditor) (*types.MsgRegisterAuditorResponse, error) {
+	if ms.keeper.GetAuthority() != req.Authority {
+		return nil, govtypes.ErrInvalidSigner.Wrapf("invalid authority; expected %s, got %s", ms.keeper.GetAuthority(), req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := ms.keeper.SetAuditor(ctx, req.Auditor); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRegisterAuditorResponse{}, nil
+}
+
+// SetAuditorState defines a method that activates or suspends registered auditor
+func (ms msgServer) SetAuditorState(goCtx context.Context, req *types.MsgSetAuditorState) (*types.MsgSetAuditorStateResponse, error) {
+	if ms.keeper.GetAuthority() != req.Authority {
+		return nil, govtypes.ErrInvalidSigner.Wrapf("invalid authority; expected %s, got %s", ms.keeper.GetAuthority(), req.Authority)
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err = ms.keeper.SetAuditorState(ctx, addr, req.State); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetAuditorStateResponse{}, nil
+}
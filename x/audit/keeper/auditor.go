@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/audit/v1"
+	attrv1 "pkg.akt.dev/go/node/types/attributes/v1"
+)
+
+// scopeWildcard allows auditor to attest any attribute, or any attribute under a key prefix when used as suffix
+const scopeWildcard = "*"
+
+// GetAuditor returns auditor registry record of given address
+func (k Keeper) GetAuditor(ctx sdk.Context, addr sdk.AccAddress) (types.Auditor, bool) {
+	store := ctx.KVStore(k.skey)
+
+	buf := store.Get(AuditorKey(addr))
+	if buf == nil {
+		return types.Auditor{}, false
+	}
+
+	var auditor types.Auditor
+	k.cdc.MustUnmarshal(buf, &auditor)
+
+	return auditor, true
+}
+
+// SetAuditor creates or updates auditor registry record
+func (k Keeper) SetAuditor(ctx sdk.Context, auditor types.Auditor) error {
+	if err := auditor.Validate(); err != nil {
+		return err
+	}
+
+	addr, err := sdk.AccAddressFromBech32(auditor.Address)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.skey)
+	store.Set(AuditorKey(addr), k.cdc.MustMarshal(&auditor))
+
+	return ctx.EventManager().EmitTypedEvent(
+		&types.EventAuditorRegistered{
+			Address: auditor.Address,
+			Name:    auditor.Name,
+			Scopes:  auditor.Scopes,
+		},
+	)
+}
+
+// SetAuditorState activates or suspends registered auditor
+func (k Keeper) SetAuditorState(ctx sdk.Context, addr sdk.AccAddress, state types.Auditor_State) error {
+	auditor, found := k.GetAuditor(ctx, addr)
+	if !found {
+		return fmt.Errorf("%w: %s", types.ErrAuditorNotRegistered, addr)
+	}
+
+	if auditor.State == state {
+		return nil
+	}
+
+	auditor.State = state
+
+	store := ctx.KVStore(k.skey)
+	store.Set(AuditorKey(addr), k.cdc.MustMarshal(&auditor))
+
+	return ctx.EventManager().EmitTypedEvent(
+		&types.EventAuditorStateChanged{
+			Address: auditor.Address,
+			State:   state,
+		},
+	)
+}
+
+// WithAuditors iterates all registered auditors
+func (k Keeper) WithAuditors(ctx sdk.Context, fn func(types.Auditor) bool) {
+	store := ctx.KVStore(k.skey)
+
+	iter := storetypes.KVStorePrefixIterator(store, AuditorPrefix)
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		var auditor types.Auditor
+		k.cdc.MustUnmarshal(iter.Value(), &auditor)
+
+		if stop := fn(auditor); stop {
+			break
+		}
+	}
+}
+
+// ValidateAuditorScope checks auditor is registered, active, and every attribute key
+// is covered by its scopes
+func (k Keeper) ValidateAuditorScope(ctx sdk.Context, addr sdk.AccAddress, attr attrv1.Attributes) error {
+	auditor, found := k.GetAuditor(ctx, addr)
+	if !found {
+		return fmt.Errorf("%w: %s", types.ErrAuditorNotRegistered, addr)
+	}
+
+	if auditor.State != types.AuditorActive {
+		return fmt.Errorf("%w: %s", types.ErrAuditorSuspended, addr)
+	}
+
+	for _, entry := range attr {
+		if !AuditorHasScope(auditor, entry.Key) {
+			return fmt.Errorf("%w: auditor %s may not attest \"%s\"", types.ErrAttributeOutOfScope, addr, entry.Key)
+		}
+	}
+
+	return nil
+}
+
+// AuditorHasScope checks whether any of auditor scopes covers given attribute key.
+// Scope is either exact attribute key, "*", or key prefix followed by "*", e.g. "capabilities/gpu/*"
+func AuditorHasScope(auditor types.Auditor, key string) bool {
+	for _, scope := range auditor.Scopes {
+		if scope == scopeWildcard || scope == key {
+			return true
+		}
+
+		if prefix, valid := strings.CutSuffix(scope, scopeWildcard); valid && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	epochstypes "pkg.akt.dev/go/node/epochs/v1beta1"
+)
+
+var _ epochstypes.EpochHooks = Keeper{}
+
+// AfterEpochEnd is called at the end of each epoch. If the epoch matches the
+// configured expiry_epoch, it prunes expired attestations and notifies about
+// attestations expiring within the notice window.
+func (k Keeper) AfterEpochEnd(ctx context.Context, epochIdentifier string, _ int64) error {
+	sctx := sdk.UnwrapSDKContext(ctx)
+
+	params := k.GetParams(sctx)
+
+	if epochIdentifier != params.ExpiryEpoch {
+		return nil
+	}
+
+	pruned, err := k.PruneExpiredAttestations(sctx, params.MaxPrunePerEpoch)
+	if err != nil {
+		sctx.Logger().Error("failed to prune expired attestations", "error", err)
+	}
+
+	if pruned > 0 {
+		sctx.Logger().Info("pruned expired attestations", "pruned", pruned)
+	}
+
+	if params.ExpiryNoticeWindow > 0 {
+		if _, err = k.NotifyExpiringAttestations(sctx, params.ExpiryNoticeWindow, params.MaxPrunePerEpoch); err != nil {
+			sctx.Logger().Error("failed to notify expiring attestations", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// BeforeEpochStart is a no-op for the audit module.
+func (k Keeper) BeforeEpochStart(_ context.Context, _ string, _ int64) error {
+	return nil
+}
+
+// EpochHooksFor returns an EpochHooks wrapper suitable for passing to
+// epochs.SetHooks via MultiEpochHooks.
+func EpochHooksFor(k Keeper) epochstypes.EpochHooks {
+	return k
+}
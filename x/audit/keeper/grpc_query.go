@@ -3,6 +3,8 @@ package keeper
 import (
 	"context"
 
+	"cosmossdk.io/store/prefix"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -30,18 +32,19 @@ func (q Querier) AllProvidersAttributes(
 	var providers types.AuditedProviders
 	ctx := sdk.UnwrapSDKContext(c)
 
-	store := ctx.KVStore(q.skey)
+	store := prefix.NewStore(ctx.KVStore(q.skey), types.PrefixProviderID())
 
-	pageRes, err := sdkquery.Paginate(store, req.Pagination, func(_ []byte, value []byte) error {
-		var provider types.AuditedProvider
+	pageRes, err := sdkquery.FilteredPaginate(store, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		provider, valid := q.auditedProviderFromStore(ctx, key, value)
+		if !valid {
+			return false, nil
+		}
 
-		err := q.cdc.Unmarshal(value, &provider)
-		if err != nil {
-			return err
+		if accumulate {
+			providers = append(providers, provider)
 		}
 
-		providers = append(providers, provider)
-		return nil
+		return true, nil
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -124,25 +127,106 @@ func (q Querier) AuditorAttributes(
 	var providers types.AuditedProviders
 	ctx := sdk.UnwrapSDKContext(c)
 
-	store := ctx.KVStore(q.skey)
+	store := prefix.NewStore(ctx.KVStore(q.skey), types.PrefixProviderID())
+
+	pageRes, err := sdkquery.FilteredPaginate(store, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		provider, valid := q.auditedProviderFromStore(ctx, key, value)
+		if !valid {
+			return false, nil
+		}
+
+		if accumulate {
+			providers = append(providers, provider)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryProvidersResponse{
+		Providers:  providers,
+		Pagination: pageRes,
+	}, nil
+}
+
+func (q Querier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryParamsResponse{Params: q.GetParams(ctx)}, nil
+}
+
+func (q Querier) Auditors(c context.Context, req *types.QueryAuditorsRequest) (*types.QueryAuditorsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	var auditors []types.Auditor
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := prefix.NewStore(ctx.KVStore(q.skey), AuditorPrefix)
 
 	pageRes, err := sdkquery.Paginate(store, req.Pagination, func(_ []byte, value []byte) error {
-		var provider types.AuditedProvider
+		var auditor types.Auditor
 
-		err := q.cdc.Unmarshal(value, &provider)
-		if err != nil {
+		if err := q.cdc.Unmarshal(value, &auditor); err != nil {
 			return err
 		}
 
-		providers = append(providers, provider)
+		auditors = append(auditors, auditor)
 		return nil
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &types.QueryProvidersResponse{
-		Providers:  providers,
+	return &types.QueryAuditorsResponse{
+		Auditors:   auditors,
 		Pagination: pageRes,
 	}, nil
 }
+
+func (q Querier) Auditor(c context.Context, req *types.QueryAuditorRequest) (*types.QueryAuditorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, types.ErrInvalidAddress
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	auditor, found := q.GetAuditor(ctx, addr)
+	if !found {
+		return nil, types.ErrAuditorNotRegistered
+	}
+
+	return &types.QueryAuditorResponse{Auditor: auditor}, nil
+}
+
+// auditedProviderFromStore decodes provider store record. Expired attestations are reported as invalid.
+func (q Querier) auditedProviderFromStore(ctx sdk.Context, key []byte, value []byte) (types.AuditedProvider, bool) {
+	var attr types.AuditedAttributesStore
+	q.cdc.MustUnmarshal(value, &attr)
+
+	if isAttestationExpired(ctx, attr) {
+		return types.AuditedProvider{}, false
+	}
+
+	id := ParseIDFromKey(append(types.PrefixProviderID(), key...))
+
+	return types.AuditedProvider{
+		Owner:        id.Owner.String(),
+		Auditor:      id.Auditor.String(),
+		Attributes:   attr.Attributes,
+		ExpiresAt:    attr.ExpiresAt,
+		EvidenceHash: attr.EvidenceHash,
+	}, true
+}
@@ -48,7 +48,7 @@ func TestGRPCQueryProvider(t *testing.T) {
 
 	// creating provider
 	id, provider := testutil.AuditedProvider(t)
-	err := suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id, provider.Attributes)
+	err := suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id, provider.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	var req *types.QueryProviderAuditorRequest
@@ -114,11 +114,11 @@ func TestGRPCQueryProviders(t *testing.T) {
 
 	// creating providers
 	id1, provider := testutil.AuditedProvider(t)
-	err := suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id1, provider.Attributes)
+	err := suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id1, provider.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	id2, provider2 := testutil.AuditedProvider(t)
-	err = suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id2, provider2.Attributes)
+	err = suite.keeper.CreateOrUpdateProviderAttributes(suite.ctx, id2, provider2.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	var req *types.QueryAllProvidersAttributesRequest
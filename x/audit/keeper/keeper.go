@@ -1,7 +1,10 @@
 package keeper
 
 import (
+	"bytes"
+	"fmt"
 	"sort"
+	"time"
 
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -14,21 +17,67 @@ import (
 type IKeeper interface {
 	GetProviderByAuditor(ctx sdk.Context, id types.ProviderID) (types.AuditedProvider, bool)
 	GetProviderAttributes(ctx sdk.Context, id sdk.Address) (types.AuditedProviders, bool)
-	CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.ProviderID, attr attrv1.Attributes) error
+	CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.ProviderID, attr attrv1.Attributes, expiresAt *time.Time, evidenceHash []byte) error
 	DeleteProviderAttributes(ctx sdk.Context, id types.ProviderID, keys []string) error
+	PruneExpiredAttestations(ctx sdk.Context, limit int64) (int64, error)
+	NotifyExpiringAttestations(ctx sdk.Context, window time.Duration, limit int64) (int64, error)
 	WithProviders(ctx sdk.Context, fn func(types.AuditedProvider) bool)
 	WithProvider(ctx sdk.Context, id sdk.Address, fn func(types.AuditedProvider) bool)
+	GetParams(ctx sdk.Context) types.Params
+	SetParams(ctx sdk.Context, params types.Params) error
+	GetAuthority() string
+	GetAuditor(ctx sdk.Context, addr sdk.AccAddress) (types.Auditor, bool)
+	SetAuditor(ctx sdk.Context, auditor types.Auditor) error
+	SetAuditorState(ctx sdk.Context, addr sdk.AccAddress, state types.Auditor_State) error
+	WithAuditors(ctx sdk.Context, fn func(types.Auditor) bool)
+	ValidateAuditorScope(ctx sdk.Context, addr sdk.AccAddress, attr attrv1.Attributes) error
 }
 
 // Keeper of the provider store
 type Keeper struct {
 	skey storetypes.StoreKey
 	cdc  codec.BinaryCodec
+
+	// The address capable of executing a MsgUpdateParams message.
+	// This should be the x/gov module account.
+	authority string
 }
 
 // NewKeeper creates and returns an instance for Market keeper
-func NewKeeper(cdc codec.BinaryCodec, skey storetypes.StoreKey) Keeper {
-	return Keeper{cdc: cdc, skey: skey}
+func NewKeeper(cdc codec.BinaryCodec, skey storetypes.StoreKey, authority string) Keeper {
+	return Keeper{cdc: cdc, skey: skey, authority: authority}
+}
+
+// GetAuthority returns the x/audit module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// SetParams sets the x/audit module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.skey)
+	store.Set(ParamsKey, k.cdc.MustMarshal(&params))
+
+	return nil
+}
+
+// GetParams returns the current x/audit module parameters.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.skey)
+
+	bz := store.Get(ParamsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	k.cdc.MustUnmarshal(bz, &params)
+
+	return params
 }
 
 // Codec returns keeper codec
@@ -53,14 +102,21 @@ func (k Keeper) GetProviderByAuditor(ctx sdk.Context, id types.ProviderID) (type
 	var sVal types.AuditedAttributesStore
 	k.cdc.MustUnmarshal(buf, &sVal)
 
+	if isAttestationExpired(ctx, sVal) {
+		return types.AuditedProvider{}, false
+	}
+
 	return types.AuditedProvider{
-		Owner:      id.Owner.String(),
-		Auditor:    id.Auditor.String(),
-		Attributes: sVal.Attributes,
+		Owner:        id.Owner.String(),
+		Auditor:      id.Auditor.String(),
+		Attributes:   sVal.Attributes,
+		ExpiresAt:    sVal.ExpiresAt,
+		EvidenceHash: sVal.EvidenceHash,
 	}, true
 }
 
-// GetProviderAttributes returns a provider with given auditor and owner id's
+// GetProviderAttributes returns a provider with given auditor and owner id's.
+// Expired attestations are not included.
 func (k Keeper) GetProviderAttributes(ctx sdk.Context, id sdk.Address) (types.AuditedProviders, bool) {
 	store := ctx.KVStore(k.skey)
 
@@ -77,10 +133,17 @@ func (k Keeper) GetProviderAttributes(ctx sdk.Context, id sdk.Address) (types.Au
 
 		var sVal types.AuditedAttributesStore
 		k.cdc.MustUnmarshal(iter.Value(), &sVal)
+
+		if isAttestationExpired(ctx, sVal) {
+			continue
+		}
+
 		res = append(res, types.AuditedProvider{
-			Owner:      id.String(),
-			Auditor:    aID.Auditor.String(),
-			Attributes: sVal.Attributes,
+			Owner:        id.String(),
+			Auditor:      aID.Auditor.String(),
+			Attributes:   sVal.Attributes,
+			ExpiresAt:    sVal.ExpiresAt,
+			EvidenceHash: sVal.EvidenceHash,
 		})
 	}
 
@@ -94,12 +157,20 @@ func (k Keeper) GetProviderAttributes(ctx sdk.Context, id sdk.Address) (types.Au
 // CreateOrUpdateProviderAttributes update signed provider attributes.
 // creates new if key does not exist
 // if key exists, existing values for matching pairs will be replaced
-func (k Keeper) CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.ProviderID, attr attrv1.Attributes) error {
+// Expiry and evidence hash apply to whole attestation and replace previous ones,
+// attributes of the expired attestation are not carried over.
+func (k Keeper) CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.ProviderID, attr attrv1.Attributes, expiresAt *time.Time, evidenceHash []byte) error {
+	if expiresAt != nil && !expiresAt.After(ctx.BlockTime()) {
+		return fmt.Errorf("%w: attestation expiry %s is not after current block time", types.ErrInvalidAttestation, expiresAt.UTC())
+	}
+
 	store := ctx.KVStore(k.skey)
 	key := ProviderKey(id)
 
 	attrRec := types.AuditedAttributesStore{
-		Attributes: attr,
+		Attributes:   attr,
+		ExpiresAt:    expiresAt,
+		EvidenceHash: evidenceHash,
 	}
 
 	buf := store.Get(key)
@@ -107,6 +178,12 @@ func (k Keeper) CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.Provi
 		tmp := types.AuditedAttributesStore{}
 		k.cdc.MustUnmarshal(buf, &tmp)
 
+		k.deleteAttestationExpiry(ctx, id, tmp)
+
+		if isAttestationExpired(ctx, tmp) {
+			tmp.Attributes = nil
+		}
+
 		kv := make(map[string]string)
 
 		for _, entry := range tmp.Attributes {
@@ -133,6 +210,10 @@ func (k Keeper) CreateOrUpdateProviderAttributes(ctx sdk.Context, id types.Provi
 
 	store.Set(key, k.cdc.MustMarshal(&attrRec))
 
+	if attrRec.ExpiresAt != nil {
+		store.Set(AttestationExpiryKey(*attrRec.ExpiresAt, id), []byte{})
+	}
+
 	err := ctx.EventManager().EmitTypedEvent(
 		&types.EventTrustedAuditorCreated{
 			Owner:   id.Owner.String(),
@@ -155,13 +236,17 @@ func (k Keeper) DeleteProviderAttributes(ctx sdk.Context, id types.ProviderID, k
 		return types.ErrProviderNotFound
 	}
 
+	tmp := types.AuditedAttributesStore{}
+	k.cdc.MustUnmarshal(buf, &tmp)
+
 	if keys == nil {
 		store.Delete(key)
+		k.deleteAttestationExpiry(ctx, id, tmp)
 	} else {
-		prov := types.AuditedAttributesStore{}
-
-		tmp := types.AuditedAttributesStore{}
-		k.cdc.MustUnmarshal(buf, &tmp)
+		prov := types.AuditedAttributesStore{
+			ExpiresAt:    tmp.ExpiresAt,
+			EvidenceHash: tmp.EvidenceHash,
+		}
 
 		kv := make(map[string]string)
 
@@ -191,6 +276,7 @@ func (k Keeper) DeleteProviderAttributes(ctx sdk.Context, id types.ProviderID, k
 
 		if len(attr) == 0 {
 			store.Delete(key)
+			k.deleteAttestationExpiry(ctx, id, tmp)
 		} else {
 			sort.SliceStable(attr, func(i, j int) bool {
 				return attr[i].Key < attr[j].Key
@@ -231,9 +317,11 @@ func (k Keeper) WithProviders(ctx sdk.Context, fn func(types.AuditedProvider) bo
 		k.cdc.MustUnmarshal(iter.Value(), &attr)
 
 		val := types.AuditedProvider{
-			Owner:      id.Owner.String(),
-			Auditor:    id.Auditor.String(),
-			Attributes: attr.Attributes,
+			Owner:        id.Owner.String(),
+			Auditor:      id.Auditor.String(),
+			Attributes:   attr.Attributes,
+			ExpiresAt:    attr.ExpiresAt,
+			EvidenceHash: attr.EvidenceHash,
 		}
 
 		if stop := fn(val); stop {
@@ -257,9 +345,11 @@ func (k Keeper) WithProvider(ctx sdk.Context, id sdk.Address, fn func(types.Audi
 		k.cdc.MustUnmarshal(iter.Value(), &attr)
 
 		val := types.AuditedProvider{
-			Owner:      id.String(),
-			Auditor:    aID.Auditor.String(),
-			Attributes: attr.Attributes,
+			Owner:        id.String(),
+			Auditor:      aID.Auditor.String(),
+			Attributes:   attr.Attributes,
+			ExpiresAt:    attr.ExpiresAt,
+			EvidenceHash: attr.EvidenceHash,
 		}
 		k.cdc.MustUnmarshal(iter.Value(), &val)
 		if stop := fn(val); stop {
@@ -267,3 +357,111 @@ func (k Keeper) WithProvider(ctx sdk.Context, id sdk.Address, fn func(types.Audi
 		}
 	}
 }
+
+// PruneExpiredAttestations deletes up to limit attestations which expiry has passed.
+// It returns number of deleted attestations.
+func (k Keeper) PruneExpiredAttestations(ctx sdk.Context, limit int64) (int64, error) {
+	store := ctx.KVStore(k.skey)
+
+	// attestation is expired at its expiry time
+	iter := store.Iterator(AttestationExpiryPrefix, AttestationExpiryTimePrefix(ctx.BlockTime().Add(time.Second)))
+
+	var keys [][]byte
+
+	for ; iter.Valid() && int64(len(keys)) < limit; iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	_ = iter.Close()
+
+	var count int64
+
+	for _, ekey := range keys {
+		_, id := ParseAttestationExpiryKey(ekey)
+
+		key := ProviderKey(id)
+
+		buf := store.Get(key)
+		if buf == nil {
+			store.Delete(ekey)
+			continue
+		}
+
+		var attr types.AuditedAttributesStore
+		k.cdc.MustUnmarshal(buf, &attr)
+
+		// index has seconds precision, attestation may expire later within current second
+		if !isAttestationExpired(ctx, attr) {
+			continue
+		}
+
+		store.Delete(ekey)
+		store.Delete(key)
+		count++
+
+		err := ctx.EventManager().EmitTypedEvent(
+			&types.EventProviderAttestationExpired{
+				Owner:        id.Owner.String(),
+				Auditor:      id.Auditor.String(),
+				ExpiresAt:    *attr.ExpiresAt,
+				EvidenceHash: attr.EvidenceHash,
+			},
+		)
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// NotifyExpiringAttestations emits event for up to limit attestations expiring within given window
+// from current block time. It returns number of emitted events.
+func (k Keeper) NotifyExpiringAttestations(ctx sdk.Context, window time.Duration, limit int64) (int64, error) {
+	store := ctx.KVStore(k.skey)
+
+	start := AttestationExpiryTimePrefix(ctx.BlockTime().Add(time.Second))
+	end := AttestationExpiryTimePrefix(ctx.BlockTime().Add(window))
+
+	if bytes.Compare(start, end) >= 0 {
+		return 0, nil
+	}
+
+	iter := store.Iterator(start, end)
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var count int64
+
+	for ; iter.Valid() && count < limit; iter.Next() {
+		expiresAt, id := ParseAttestationExpiryKey(iter.Key())
+
+		err := ctx.EventManager().EmitTypedEvent(
+			&types.EventProviderAttestationExpiring{
+				Owner:     id.Owner.String(),
+				Auditor:   id.Auditor.String(),
+				ExpiresAt: expiresAt,
+			},
+		)
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func (k Keeper) deleteAttestationExpiry(ctx sdk.Context, id types.ProviderID, attr types.AuditedAttributesStore) {
+	if attr.ExpiresAt == nil {
+		return
+	}
+
+	ctx.KVStore(k.skey).Delete(AttestationExpiryKey(*attr.ExpiresAt, id))
+}
+
+func isAttestationExpired(ctx sdk.Context, attr types.AuditedAttributesStore) bool {
+	return attr.ExpiresAt != nil && !ctx.BlockTime().Before(*attr.ExpiresAt)
+}
@@ -17,6 +17,8 @@ import (
 	dbm "github.com/cosmos/cosmos-db"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	testutilmod "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/audit/v1"
 	"pkg.akt.dev/go/testutil"
@@ -28,7 +30,7 @@ func TestProviderCreate(t *testing.T) {
 	ctx, keeper := setupKeeper(t)
 	id, prov := testutil.AuditedProvider(t)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	foundProv, found := keeper.GetProviderAttributes(ctx, id.Owner)
@@ -40,7 +42,7 @@ func TestProviderUpdateAppendNewAttributes(t *testing.T) {
 	ctx, keeper := setupKeeper(t)
 	id, prov := testutil.AuditedProvider(t)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	attr := prov.Attributes
@@ -52,7 +54,7 @@ func TestProviderUpdateAppendNewAttributes(t *testing.T) {
 		return attr[i].Key < attr[j].Key
 	})
 
-	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	prov.Attributes = attr
@@ -66,7 +68,7 @@ func TestProviderUpdateOverrideAttributes(t *testing.T) {
 	ctx, keeper := setupKeeper(t)
 	id, prov := testutil.AuditedProvider(t)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	for i := range prov.Attributes {
@@ -77,7 +79,7 @@ func TestProviderUpdateOverrideAttributes(t *testing.T) {
 		return prov.Attributes[i].Key < prov.Attributes[j].Key
 	})
 
-	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	foundProv, found := keeper.GetProviderAttributes(ctx, id.Owner)
@@ -92,7 +94,7 @@ func TestProviderDeleteExistingAttributes(t *testing.T) {
 	// lets append some more attributes in case testutil generated only 1
 	prov.Attributes = append(prov.Attributes, testutil.Attributes(t)...)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	err = keeper.DeleteProviderAttributes(ctx, id, []string{prov.Attributes[0].Key})
@@ -113,7 +115,7 @@ func TestProviderDeleteNonExistingAttributes(t *testing.T) {
 	ctx, keeper := setupKeeper(t)
 	id, prov := testutil.AuditedProvider(t)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	attr := testutil.Attributes(t)
@@ -131,7 +133,7 @@ func TestProviderDeleteExisting(t *testing.T) {
 	ctx, keeper := setupKeeper(t)
 	id, prov := testutil.AuditedProvider(t)
 
-	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes)
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, nil, nil)
 	require.NoError(t, err)
 
 	err = keeper.DeleteProviderAttributes(ctx, id, nil)
@@ -149,6 +151,80 @@ func TestProviderDeleteNonExisting(t *testing.T) {
 	require.EqualError(t, err, types.ErrProviderNotFound.Error())
 }
 
+func TestProviderExpire(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	id, prov := testutil.AuditedProvider(t)
+
+	expired := ctx.BlockTime()
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, &expired, nil)
+	require.ErrorIs(t, err, types.ErrInvalidAttestation)
+
+	expiresAt := ctx.BlockTime().Add(time.Hour)
+	evidence := []byte("evidence")
+
+	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, &expiresAt, evidence)
+	require.NoError(t, err)
+
+	foundProv, found := keeper.GetProviderAttributes(ctx, id.Owner)
+	require.True(t, found)
+	require.Len(t, foundProv, 1)
+	require.Equal(t, evidence, foundProv[0].EvidenceHash)
+
+	notified, err := keeper.NotifyExpiringAttestations(ctx, 2*time.Hour, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), notified)
+
+	pruned, err := keeper.PruneExpiredAttestations(ctx, 10)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	ctx = ctx.WithBlockTime(expiresAt)
+
+	_, found = keeper.GetProviderAttributes(ctx, id.Owner)
+	require.False(t, found)
+
+	_, found = keeper.GetProviderByAuditor(ctx, id)
+	require.False(t, found)
+
+	pruned, err = keeper.PruneExpiredAttestations(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pruned)
+
+	err = keeper.DeleteProviderAttributes(ctx, id, nil)
+	require.EqualError(t, err, types.ErrProviderNotFound.Error())
+}
+
+func TestProviderRenewExpired(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	id, prov := testutil.AuditedProvider(t)
+
+	expiresAt := ctx.BlockTime().Add(time.Hour)
+
+	err := keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, &expiresAt, nil)
+	require.NoError(t, err)
+
+	ctx = ctx.WithBlockTime(expiresAt.Add(time.Minute))
+
+	// attributes of the expired attestation are not carried over
+	prov.Attributes = testutil.Attributes(t)
+	sort.Stable(prov.Attributes)
+
+	renewedAt := ctx.BlockTime().Add(time.Hour)
+
+	err = keeper.CreateOrUpdateProviderAttributes(ctx, id, prov.Attributes, &renewedAt, nil)
+	require.NoError(t, err)
+
+	foundProv, found := keeper.GetProviderAttributes(ctx, id.Owner)
+	require.True(t, found)
+	require.Len(t, foundProv, 1)
+	require.Equal(t, prov.Attributes, foundProv[0].Attributes)
+
+	// stale expiry index entry has been replaced
+	pruned, err := keeper.PruneExpiredAttestations(ctx, 10)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+}
+
 func TestKeeperCoder(t *testing.T) {
 	_, keeper := setupKeeper(t)
 	codec := keeper.Codec()
@@ -171,5 +247,5 @@ func setupKeeper(t testing.TB) (sdk.Context, keeper.Keeper) {
 	require.NoError(t, err)
 
 	ctx := sdk.NewContext(ms, tmproto.Header{Time: time.Unix(0, 0)}, false, testutil.Logger(t))
-	return ctx, keeper.NewKeeper(cdc, key)
+	return ctx, keeper.NewKeeper(cdc, key, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 }
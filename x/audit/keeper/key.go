@@ -2,8 +2,10 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
@@ -13,6 +15,12 @@ import (
 	"pkg.akt.dev/node/v2/util/validation"
 )
 
+var (
+	ParamsKey               = []byte{0x10}
+	AttestationExpiryPrefix = []byte{0x11}
+	AuditorPrefix           = []byte{0x12}
+)
+
 func ProviderKey(id types.ProviderID) []byte {
 	buf := bytes.NewBuffer(types.PrefixProviderID())
 	if _, err := buf.Write(address.MustLengthPrefix(id.Owner.Bytes())); err != nil {
@@ -66,3 +74,57 @@ func ParseIDFromKey(key []byte) types.ProviderID {
 		Auditor: sdk.AccAddress(auditor),
 	}
 }
+
+// AttestationExpiryKey creates an expiry index key of the format:
+// prefix_bytes | expires at unix seconds (8 bytes big endian) | owner_address_len (1 byte) | owner_address_bytes | auditor_address_len (1 byte) | auditor_address_bytes
+func AttestationExpiryKey(expiresAt time.Time, id types.ProviderID) []byte {
+	buf := bytes.NewBuffer(AttestationExpiryTimePrefix(expiresAt))
+	if _, err := buf.Write(address.MustLengthPrefix(id.Owner.Bytes())); err != nil {
+		panic(err)
+	}
+
+	if _, err := buf.Write(address.MustLengthPrefix(id.Auditor.Bytes())); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
+
+// AttestationExpiryTimePrefix returns expiry index prefix of attestations expiring at given time.
+// Times before unix epoch are clamped to it.
+func AttestationExpiryTimePrefix(tm time.Time) []byte {
+	ts := tm.Unix()
+	if ts < 0 {
+		ts = 0
+	}
+
+	res := make([]byte, len(AttestationExpiryPrefix), len(AttestationExpiryPrefix)+8)
+	copy(res, AttestationExpiryPrefix)
+
+	return binary.BigEndian.AppendUint64(res, uint64(ts))
+}
+
+// ParseAttestationExpiryKey parse attestation expiry index key into expiry time and provider id
+func ParseAttestationExpiryKey(key []byte) (time.Time, types.ProviderID) {
+	validation.AssertKeyAtLeastLength(key, len(AttestationExpiryPrefix)+8)
+	if !bytes.HasPrefix(key, AttestationExpiryPrefix) {
+		panic(fmt.Sprintf("invalid key prefix. expected 0x%s, actual 0x%s", hex.EncodeToString(AttestationExpiryPrefix), hex.EncodeToString(key[:1])))
+	}
+
+	key = key[len(AttestationExpiryPrefix):]
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(key[:8])), 0).UTC() // nolint: gosec
+
+	// remaining part of the key has the same layout as provider key
+	return expiresAt, ParseIDFromKey(append(types.PrefixProviderID(), key[8:]...))
+}
+
+// AuditorKey creates an auditor registry key of the format:
+// prefix_bytes | auditor_address_len (1 byte) | auditor_address_bytes
+func AuditorKey(addr sdk.AccAddress) []byte {
+	buf := bytes.NewBuffer(AuditorPrefix)
+	if _, err := buf.Write(address.MustLengthPrefix(addr.Bytes())); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
@@ -12,6 +12,8 @@ import (
 
 	types "pkg.akt.dev/go/node/bme/v1"
 	"pkg.akt.dev/go/sdkutil"
+
+	"pkg.akt.dev/node/v2/util/metrics"
 )
 
 // BeginBlocker is called at the beginning of each block
@@ -44,6 +46,13 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 	}
 
 	var processed int64
+	var executed, canceled, retried int
+
+	defer func() {
+		metrics.AddEndBlockerProcessed(types.ModuleName, "records_executed", executed)
+		metrics.AddEndBlockerProcessed(types.ModuleName, "records_canceled", canceled)
+		metrics.AddEndBlockerProcessed(types.ModuleName, "records_retried", retried)
+	}()
 
 	executeMint := func(id types.LedgerRecordID, value types.LedgerPendingRecord) (bool, error) {
 		ownerAddr, err := k.ac.StringToBytes(value.Owner)
@@ -64,6 +73,7 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 		err = k.executeBurnMint(cacheCtx, params, id, ownerAddr, dstAddr, value.CoinsToBurn, value.DenomToMint)
 		if err == nil {
 			writeCache()
+			executed++
 			processed++
 			return processed >= int64(params.MaxEndblockerRecords), nil
 		}
@@ -78,6 +88,7 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 				return false, cancelErr
 			}
 			writeCancel()
+			canceled++
 		} else {
 			// Retriable error: increment attempts
 			value.Attempts++
@@ -89,12 +100,14 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 					return false, cancelErr
 				}
 				writeCancel()
+				canceled++
 			} else {
 				// Still has attempts: update pending record in-place
 				if updErr := k.ledgerPending.Set(sctx, id, value); updErr != nil {
 					sctx.Logger().Error("failed to update pending record attempts", "id", id, "err", updErr)
 					return false, updErr
 				}
+				retried++
 			}
 		}
 
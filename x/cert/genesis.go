@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -22,7 +23,27 @@ func ValidateGenesis(data *types.GenesisState) error {
 		}
 	}
 
-	return nil
+	revocations := make(map[string]struct{}, len(data.Revocations))
+
+	for idx, record := range data.Revocations {
+		id, err := types.ToCertID(record.ID)
+		if err != nil {
+			return fmt.Errorf("%w: invalid certificate revocation (idx %v)", err, idx)
+		}
+
+		if record.Height < 0 {
+			return fmt.Errorf("%w: negative revocation height of certificate %s/%s (idx %v)", types.ErrInvalidCertificateValue, record.ID.Owner, record.ID.Serial, idx)
+		}
+
+		key := string(keeper.MustCertificateRevokedKey(0, id))
+		if _, exists := revocations[key]; exists {
+			return fmt.Errorf("%w: duplicate revocation of certificate %s/%s (idx %v)", types.ErrCertificateAlreadyRevoked, record.ID.Owner, record.ID.Serial, idx)
+		}
+
+		revocations[key] = struct{}{}
+	}
+
+	return data.Params.Validate()
 }
 
 // InitGenesis initiate genesis state and return updated validator details
@@ -30,6 +51,17 @@ func InitGenesis(ctx sdk.Context, kpr keeper.Keeper, data *types.GenesisState) {
 	store := ctx.KVStore(kpr.StoreKey())
 	cdc := kpr.Codec()
 
+	revocations := make(map[string]types.GenesisCertificateRevocation, len(data.Revocations))
+
+	for _, record := range data.Revocations {
+		id, err := types.ToCertID(record.ID)
+		if err != nil {
+			panic(fmt.Sprintf("error init certificate revocation from genesis: %s", err))
+		}
+
+		revocations[string(keeper.MustCertificateRevokedKey(0, id))] = record
+	}
+
 	for _, record := range data.Certificates {
 		owner, err := sdk.AccAddressFromBech32(record.Owner)
 		if err != nil {
@@ -41,16 +73,55 @@ func InitGenesis(ctx sdk.Context, kpr keeper.Keeper, data *types.GenesisState) {
 			panic(err.Error())
 		}
 
-		key := keeper.MustCertificateKey(record.Certificate.State, types.CertID{
+		id := types.CertID{
 			Owner:  owner,
 			Serial: *cert.SerialNumber,
-		})
+		}
+
+		key := keeper.MustCertificateKey(record.Certificate.State, id)
 
 		if store.Has(key) {
 			panic(types.ErrCertificateExists.Error())
 		}
 
 		store.Set(key, cdc.MustMarshal(&record.Certificate))
+
+		switch record.Certificate.State {
+		case types.CertificateValid:
+			store.Set(keeper.MustCertificateExpiryKey(cert.NotAfter, id), []byte{})
+		case types.CertificateRevoked:
+			// certificates revoked before revocation heights were tracked carry no revocation record
+			// and are listed as revoked at height 0
+			var height int64
+			revokedAt := ctx.BlockTime()
+
+			rkey := string(keeper.MustCertificateRevokedKey(0, id))
+			if revocation, exists := revocations[rkey]; exists {
+				height = revocation.Height
+				revokedAt = revocation.RevokedAt
+				delete(revocations, rkey)
+			}
+
+			store.Set(keeper.MustCertificateRevokedKey(height, id), keeper.CertificateTimeValue(revokedAt))
+		}
+	}
+
+	if len(revocations) > 0 {
+		panic(fmt.Sprintf("error init certificate revocations from genesis: %d revocations of unknown certificates", len(revocations)))
+	}
+
+	for _, record := range data.Rotations {
+		id, err := types.ToCertID(record.ID)
+		if err != nil {
+			panic(fmt.Sprintf("error init certificate rotation from genesis: %s", err))
+		}
+
+		store.Set(keeper.MustCertificateRotationKey(id), keeper.CertificateTimeValue(record.RevokeAt))
+		store.Set(keeper.MustCertificateRevocationKey(record.RevokeAt, id), []byte{})
+	}
+
+	if err := kpr.SetParams(ctx, data.Params); err != nil {
+		panic(fmt.Sprintf("error init cert params from genesis: %s", err))
 	}
 }
 
@@ -81,15 +152,49 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		return false
 	})
 
+	var rotations []types.GenesisCertificateRotation
+
+	k.WithCertificateRotations(ctx, func(id types.CertID, revokeAt time.Time) bool {
+		rotations = append(rotations, types.GenesisCertificateRotation{
+			ID: types.ID{
+				Owner:  id.Owner.String(),
+				Serial: id.Serial.String(),
+			},
+			RevokeAt: revokeAt,
+		})
+
+		return false
+	})
+
+	var revocations []types.GenesisCertificateRevocation
+
+	k.WithCertificatesRevoked(ctx, 0, func(id types.CertID, height int64, revokedAt time.Time) bool {
+		revocations = append(revocations, types.GenesisCertificateRevocation{
+			ID: types.ID{
+				Owner:  id.Owner.String(),
+				Serial: id.Serial.String(),
+			},
+			Height:    height,
+			RevokedAt: revokedAt,
+		})
+
+		return false
+	})
+
 	return &types.GenesisState{
 		Certificates: res,
+		Rotations:    rotations,
+		Revocations:  revocations,
+		Params:       k.GetParams(ctx),
 	}
 }
 
 // DefaultGenesisState returns default genesis state as raw bytes for the provider
 // module.
 func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{}
+	return &types.GenesisState{
+		Params: types.DefaultParams(),
+	}
 }
 
 // GetGenesisStateFromAppState returns x/cert GenesisState given raw application
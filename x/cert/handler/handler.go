@@ -22,6 +22,12 @@ func NewHandler(keeper keeper.Keeper) baseapp.MsgServiceHandler {
 		case *types.MsgRevokeCertificate:
 			res, err := ms.RevokeCertificate(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRotateCertificate:
+			res, err := ms.RotateCertificate(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUpdateParams:
+			res, err := ms.UpdateParams(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		}
 
 		return nil, sdkerrors.ErrUnknownRequest.Wrapf("unrecognized message type: %T", msg)
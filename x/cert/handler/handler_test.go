@@ -18,6 +18,8 @@ import (
 	sdktestdata "github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/cert/v1"
 	"pkg.akt.dev/go/testutil"
@@ -57,7 +59,7 @@ func setupTestSuite(t *testing.T) *testSuite {
 
 	suite.ctx = sdk.NewContext(suite.ms, tmproto.Header{}, true, testutil.Logger(t))
 
-	suite.keeper = keeper.NewKeeper(cdc, aKey)
+	suite.keeper = keeper.NewKeeper(cdc, aKey, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 
 	suite.handler = handler.NewHandler(suite.keeper)
 
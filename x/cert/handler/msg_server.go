@@ -4,6 +4,7 @@ import (
 	"context"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/cert/v1"
 
@@ -53,3 +54,32 @@ func (m msgServer) RevokeCertificate(goCtx context.Context, req *types.MsgRevoke
 
 	return &types.MsgRevokeCertificateResponse{}, nil
 }
+
+func (m msgServer) RotateCertificate(goCtx context.Context, req *types.MsgRotateCertificate) (*types.MsgRotateCertificateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	id, err := types.ToCertID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.keeper.RotateCertificate(ctx, id, req.Cert, req.Pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRotateCertificateResponse{}, nil
+}
+
+func (m msgServer) UpdateParams(goCtx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if m.keeper.GetAuthority() != req.Authority {
+		return nil, govtypes.ErrInvalidSigner.Wrapf("invalid authority; expected %s, got %s", m.keeper.GetAuthority(), req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := m.keeper.SetParams(ctx, req.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	epochstypes "pkg.akt.dev/go/node/epochs/v1beta1"
+)
+
+var _ epochstypes.EpochHooks = &keeper{}
+
+// AfterEpochEnd is called at the end of each epoch. If the epoch matches the
+// configured expiry_epoch, it expires certificates which NotAfter has passed.
+func (k *keeper) AfterEpochEnd(ctx context.Context, epochIdentifier string, _ int64) error {
+	sctx := sdk.UnwrapSDKContext(ctx)
+
+	params := k.GetParams(sctx)
+
+	if epochIdentifier != params.ExpiryEpoch {
+		return nil
+	}
+
+	expired, err := k.ExpireCertificates(sctx, params.MaxExpirePerEpoch, params.PruneExpired)
+	if err != nil {
+		sctx.Logger().Error("failed to expire certificates", "error", err)
+	}
+
+	if expired > 0 {
+		sctx.Logger().Info("expired certificates",
+			"expired", expired,
+			"pruned", params.PruneExpired,
+		)
+	}
+
+	return nil
+}
+
+// BeforeEpochStart is a no-op for the cert module.
+func (k *keeper) BeforeEpochStart(_ context.Context, _ string, _ int64) error {
+	return nil
+}
+
+// EpochHooksFor returns an EpochHooks wrapper suitable for passing to
+// epochs.SetHooks via MultiEpochHooks.
+func EpochHooksFor(k Keeper) epochstypes.EpochHooks {
+	return k.(*keeper)
+}
@@ -1,12 +1,14 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
 
@@ -65,6 +67,7 @@ func (q querier) Certificates(c context.Context, req *types.QueryCertificatesReq
 		// request does not have pagination set. Start from valid store
 		states = append(states, byte(types.CertificateValid))
 		states = append(states, byte(types.CertificateRevoked))
+		states = append(states, byte(types.CertificateExpired))
 	}
 
 	var certificates types.CertificatesResponse
@@ -145,3 +148,171 @@ func (q querier) Certificates(c context.Context, req *types.QueryCertificatesReq
 		Pagination:   pageRes,
 	}, nil
 }
+
+// CertificatesExpiring returns valid certificates which expire within requested window from current block time,
+// ordered by expiry
+func (q querier) CertificatesExpiring(c context.Context, req *types.QueryCertificatesExpiringRequest) (*types.QueryCertificatesExpiringResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Within <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "window must be positive")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var owner sdk.AccAddress
+	if req.Owner != "" {
+		var err error
+
+		owner, err = sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.Pagination == nil {
+		req.Pagination = &sdkquery.PageRequest{}
+	}
+
+	if req.Pagination.Limit == 0 {
+		req.Pagination.Limit = sdkquery.DefaultLimit
+	}
+
+	start := certTimePrefix(CertExpiryPrefix, ctx.BlockTime())
+	end := certTimePrefix(CertExpiryPrefix, ctx.BlockTime().Add(req.Within))
+
+	if len(req.Pagination.Key) > 0 {
+		if !bytes.HasPrefix(req.Pagination.Key, CertExpiryPrefix) || bytes.Compare(req.Pagination.Key, start) < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid pagination key")
+		}
+
+		start = req.Pagination.Key
+	}
+
+	store := ctx.KVStore(q.skey)
+	iter := store.Iterator(start, end)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var certificates types.CertificatesResponse
+	pageRes := &sdkquery.PageResponse{}
+
+	for ; iter.Valid(); iter.Next() {
+		_, id, err := ParseCertExpiryKey(iter.Key())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !owner.Empty() && !owner.Equals(id.Owner) {
+			continue
+		}
+
+		if uint64(len(certificates)) == req.Pagination.Limit {
+			pageRes.NextKey = iter.Key()
+			break
+		}
+
+		cert, found := q.GetCertificateByID(ctx, id)
+		if !found {
+			return nil, status.Error(codes.Internal, types.ErrCertificateNotFound.Error())
+		}
+
+		certificates = append(certificates, cert)
+	}
+
+	pageRes.Total = uint64(len(certificates))
+
+	return &types.QueryCertificatesExpiringResponse{
+		Certificates: certificates,
+		Pagination:   pageRes,
+	}, nil
+}
+
+// CertificateRevocations returns compact list of certificates revoked at or after requested height,
+// ordered by revocation height. Following request may continue from the height next to the response height.
+func (q querier) CertificateRevocations(c context.Context, req *types.QueryCertificateRevocationsRequest) (*types.QueryCertificateRevocationsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.SinceHeight < 0 {
+		return nil, status.Error(codes.InvalidArgument, "since height must not be negative")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if req.Pagination == nil {
+		req.Pagination = &sdkquery.PageRequest{}
+	}
+
+	if req.Pagination.Limit == 0 {
+		req.Pagination.Limit = sdkquery.DefaultLimit
+	}
+
+	start := certSeqPrefix(CertRevokedPrefix, clampSeq(req.SinceHeight))
+
+	if len(req.Pagination.Key) > 0 {
+		if !bytes.HasPrefix(req.Pagination.Key, CertRevokedPrefix) || bytes.Compare(req.Pagination.Key, start) < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid pagination key")
+		}
+
+		start = req.Pagination.Key
+	}
+
+	store := ctx.KVStore(q.skey)
+	iter := store.Iterator(start, storetypes.PrefixEndBytes(CertRevokedPrefix))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var revocations []types.CertificateRevocation
+	pageRes := &sdkquery.PageResponse{}
+
+	for ; iter.Valid(); iter.Next() {
+		if uint64(len(revocations)) == req.Pagination.Limit {
+			pageRes.NextKey = iter.Key()
+			break
+		}
+
+		height, id, err := ParseCertRevokedKey(iter.Key())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		revokedAt, err := ParseCertificateTimeValue(iter.Value())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		revocations = append(revocations, types.CertificateRevocation{
+			Owner:     id.Owner.String(),
+			Serial:    id.Serial.String(),
+			Height:    height,
+			RevokedAt: revokedAt,
+		})
+	}
+
+	pageRes.Total = uint64(len(revocations))
+
+	return &types.QueryCertificateRevocationsResponse{
+		Revocations: revocations,
+		Height:      ctx.BlockHeight(),
+		Pagination:  pageRes,
+	}, nil
+}
+
+// Params returns params of the cert module
+func (q querier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryParamsResponse{Params: q.GetParams(ctx)}, nil
+}
@@ -1,6 +1,10 @@
 package keeper
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -19,18 +23,31 @@ type Keeper interface {
 	WithCertificates(ctx sdk.Context, fn func(id types.CertID, certificate types.CertificateResponse) bool)
 	WithOwner(ctx sdk.Context, id sdk.Address, fn func(types.CertificateResponse) bool)
 	WithOwnerState(ctx sdk.Context, id sdk.Address, state types.State, fn func(types.CertificateResponse) bool)
+	WithCertificatesExpiring(ctx sdk.Context, from, to time.Time, fn func(id types.CertID, notAfter time.Time) bool)
+	ExpireCertificates(ctx sdk.Context, limit int64, prune bool) (int64, error)
+	RotateCertificate(ctx sdk.Context, id types.CertID, crt []byte, pubkey []byte) (types.CertID, error)
+	RevokeRotatedCertificates(ctx sdk.Context, limit int) (int, error)
+	WithCertificateRotations(ctx sdk.Context, fn func(id types.CertID, revokeAt time.Time) bool)
+	WithCertificatesRevoked(ctx sdk.Context, sinceHeight int64, fn func(id types.CertID, height int64, revokedAt time.Time) bool)
+	GetParams(ctx sdk.Context) types.Params
+	SetParams(ctx sdk.Context, params types.Params) error
+	GetAuthority() string
 }
 
 type keeper struct {
 	skey storetypes.StoreKey
 	cdc  codec.BinaryCodec
+
+	// The address capable of executing a MsgUpdateParams message.
+	// This should be the x/gov module account.
+	authority string
 }
 
 var _ Keeper = (*keeper)(nil)
 
 // NewKeeper creates and returns an instance for Market keeper
-func NewKeeper(cdc codec.BinaryCodec, skey storetypes.StoreKey) Keeper {
-	return &keeper{cdc: cdc, skey: skey}
+func NewKeeper(cdc codec.BinaryCodec, skey storetypes.StoreKey, authority string) Keeper {
+	return &keeper{cdc: cdc, skey: skey, authority: authority}
 }
 
 // Querier return gRPC query handler
@@ -38,6 +55,38 @@ func (k keeper) Querier() types.QueryServer {
 	return &querier{keeper: k}
 }
 
+// GetAuthority returns the x/cert module's authority.
+func (k keeper) GetAuthority() string {
+	return k.authority
+}
+
+// SetParams sets the x/cert module parameters.
+func (k keeper) SetParams(ctx sdk.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.skey)
+	store.Set(ParamsKey, k.cdc.MustMarshal(&params))
+
+	return nil
+}
+
+// GetParams returns the current x/cert module parameters.
+func (k keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.skey)
+
+	bz := store.Get(ParamsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	k.cdc.MustUnmarshal(bz, &params)
+
+	return params
+}
+
 // Codec returns keeper codec
 func (k keeper) Codec() codec.BinaryCodec {
 	return k.cdc
@@ -78,7 +127,7 @@ func (k keeper) CreateCertificate(ctx sdk.Context, owner sdk.Address, crt []byte
 
 	store.Set(key, k.cdc.MustMarshal(&val))
 
-	return nil
+	return k.setCertificateExpiry(ctx, id, cert.NotAfter)
 }
 
 func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
@@ -98,6 +147,14 @@ func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
 		return types.ErrCertificateAlreadyRevoked
 	}
 
+	if cert.State == types.CertificateValid {
+		if err := k.deleteCertificateExpiry(ctx, id, cert.Cert); err != nil {
+			return err
+		}
+	}
+
+	k.deleteCertificateRotation(ctx, id)
+
 	cert.State = types.CertificateRevoked
 
 	nkey, err := CertificateKey(cert.State, id)
@@ -108,9 +165,87 @@ func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
 	store.Delete(key)
 	store.Set(nkey, k.cdc.MustMarshal(&cert))
 
+	rkey, err := CertificateRevokedKey(ctx.BlockHeight(), id)
+	if err != nil {
+		return err
+	}
+
+	store.Set(rkey, CertificateTimeValue(ctx.BlockTime()))
+
 	return nil
 }
 
+// ExpireCertificates moves up to limit valid certificates which NotAfter has passed into expired state,
+// or removes them from the store when prune is set. It returns number of processed certificates.
+func (k keeper) ExpireCertificates(ctx sdk.Context, limit int64, prune bool) (int64, error) {
+	store := ctx.KVStore(k.skey)
+
+	// certificate is expired once block time is past its NotAfter
+	iter := store.Iterator(CertExpiryPrefix, certTimePrefix(CertExpiryPrefix, ctx.BlockTime()))
+
+	var keys [][]byte
+
+	for ; iter.Valid() && int64(len(keys)) < limit; iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	_ = iter.Close()
+
+	for _, ekey := range keys {
+		_, id, err := ParseCertExpiryKey(ekey)
+		if err != nil {
+			return 0, err
+		}
+
+		store.Delete(ekey)
+
+		key := MustCertificateKey(types.CertificateValid, id)
+
+		buf := store.Get(key)
+		if buf == nil {
+			continue
+		}
+
+		store.Delete(key)
+		k.deleteCertificateRotation(ctx, id)
+
+		if prune {
+			continue
+		}
+
+		var cert types.Certificate
+		k.cdc.MustUnmarshal(buf, &cert)
+
+		cert.State = types.CertificateExpired
+
+		store.Set(MustCertificateKey(cert.State, id), k.cdc.MustMarshal(&cert))
+	}
+
+	return int64(len(keys)), nil
+}
+
+// WithCertificatesExpiring iterates valid certificates which NotAfter is within [from, to) window
+// in order of expiry
+func (k keeper) WithCertificatesExpiring(ctx sdk.Context, from, to time.Time, fn func(id types.CertID, notAfter time.Time) bool) {
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(certTimePrefix(CertExpiryPrefix, from), certTimePrefix(CertExpiryPrefix, to))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		notAfter, id, err := ParseCertExpiryKey(iter.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if stop := fn(id, notAfter); stop {
+			break
+		}
+	}
+}
+
 // GetCertificateByID returns a provider with given auditor and owner id
 func (k keeper) GetCertificateByID(ctx sdk.Context, id types.CertID) (types.CertificateResponse, bool) {
 	store := ctx.KVStore(k.skey)
@@ -180,6 +315,7 @@ func (k keeper) WithOwner(ctx sdk.Context, id sdk.Address, fn func(types.Certifi
 	states := []types.State{
 		types.CertificateValid,
 		types.CertificateRevoked,
+		types.CertificateExpired,
 	}
 
 	iters := make([]storetypes.Iterator, 0, len(states))
@@ -235,6 +371,32 @@ func (k keeper) WithOwnerState(ctx sdk.Context, id sdk.Address, state types.Stat
 	}
 }
 
+// WithCertificatesRevoked iterates certificates revoked at or after given height in order of revocation
+func (k keeper) WithCertificatesRevoked(ctx sdk.Context, sinceHeight int64, fn func(id types.CertID, height int64, revokedAt time.Time) bool) {
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(certSeqPrefix(CertRevokedPrefix, clampSeq(sinceHeight)), storetypes.PrefixEndBytes(CertRevokedPrefix))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		height, id, err := ParseCertRevokedKey(iter.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		revokedAt, err := ParseCertificateTimeValue(iter.Value())
+		if err != nil {
+			panic(err)
+		}
+
+		if stop := fn(id, height, revokedAt); stop {
+			break
+		}
+	}
+}
+
 func (k keeper) unmarshal(key, val []byte) (types.CertID, types.CertificateResponse, error) {
 	_, id, err := ParseCertKey(key)
 	if err != nil {
@@ -266,6 +428,7 @@ func (k keeper) findCertificate(ctx sdk.Context, id types.CertID) []byte {
 
 	vKey := MustCertificateKey(types.CertificateValid, id)
 	rKey := MustCertificateKey(types.CertificateRevoked, id)
+	eKey := MustCertificateKey(types.CertificateExpired, id)
 
 	var key []byte
 
@@ -273,7 +436,50 @@ func (k keeper) findCertificate(ctx sdk.Context, id types.CertID) []byte {
 		key = vKey
 	} else if store.Has(rKey) {
 		key = rKey
+	} else if store.Has(eKey) {
+		key = eKey
 	}
 
 	return key
 }
+
+func (k keeper) setCertificateExpiry(ctx sdk.Context, id types.CertID, notAfter time.Time) error {
+	key, err := CertificateExpiryKey(notAfter, id)
+	if err != nil {
+		return err
+	}
+
+	ctx.KVStore(k.skey).Set(key, []byte{})
+
+	return nil
+}
+
+func (k keeper) deleteCertificateExpiry(ctx sdk.Context, id types.CertID, crt []byte) error {
+	notAfter, err := certificateNotAfter(crt)
+	if err != nil {
+		return err
+	}
+
+	key, err := CertificateExpiryKey(notAfter, id)
+	if err != nil {
+		return err
+	}
+
+	ctx.KVStore(k.skey).Delete(key)
+
+	return nil
+}
+
+func certificateNotAfter(crt []byte) (time.Time, error) {
+	block, _ := pem.Decode(crt)
+	if block == nil {
+		return time.Time{}, types.ErrInvalidCertificateValue
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
@@ -1,6 +1,8 @@
 package keeper_test
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -15,10 +17,14 @@ import (
 	dbm "github.com/cosmos/cosmos-db"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	testutilmod "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	types "pkg.akt.dev/go/node/cert/v1"
 	"pkg.akt.dev/go/testutil"
 
+	"pkg.akt.dev/node/v2/x/cert"
 	"pkg.akt.dev/node/v2/x/cert/keeper"
 )
 
@@ -205,6 +211,279 @@ func TestCertKeeperRevokeCreate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCertKeeperExpire(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+
+	cert := testutil.Certificate(t, owner)
+	cert1 := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	err = keeper.CreateCertificate(ctx, owner, cert1.PEM.Cert, cert1.PEM.Pub)
+	require.NoError(t, err)
+
+	id := types.CertID{Owner: owner, Serial: cert.Serial}
+	id1 := types.CertID{Owner: owner, Serial: cert1.Serial}
+
+	// revoked certificates are not tracked for expiry
+	err = keeper.RevokeCertificate(ctx, id1)
+	require.NoError(t, err)
+
+	notAfter := certNotAfter(t, cert.PEM.Cert)
+
+	var expiring []types.CertID
+	keeper.WithCertificatesExpiring(ctx, ctx.BlockTime(), notAfter.Add(time.Second), func(id types.CertID, _ time.Time) bool {
+		expiring = append(expiring, id)
+		return false
+	})
+	require.Len(t, expiring, 1)
+	require.Equal(t, cert.Serial.String(), expiring[0].Serial.String())
+
+	// certificate is still valid at its NotAfter
+	expired, err := keeper.ExpireCertificates(ctx.WithBlockTime(notAfter), 10, false)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+
+	expired, err = keeper.ExpireCertificates(ctx.WithBlockTime(notAfter.Add(time.Second)), 10, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+
+	resp, exists := keeper.GetCertificateByID(ctx, id)
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert, resp, types.CertificateExpired)
+
+	resp, exists = keeper.GetCertificateByID(ctx, id1)
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert1, resp, types.CertificateRevoked)
+
+	expired, err = keeper.ExpireCertificates(ctx.WithBlockTime(notAfter.Add(time.Hour)), 10, false)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+}
+
+func TestCertKeeperExpirePrune(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+	cert := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	notAfter := certNotAfter(t, cert.PEM.Cert)
+
+	expired, err := keeper.ExpireCertificates(ctx.WithBlockTime(notAfter.Add(time.Second)), 10, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+
+	_, exists := keeper.GetCertificateByID(ctx, types.CertID{
+		Owner:  owner,
+		Serial: cert.Serial,
+	})
+	require.False(t, exists)
+
+	// pruned certificate may be created again
+	err = keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+}
+
+func TestCertKeeperRotate(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	params := keeper.GetParams(ctx)
+	params.RotationGracePeriod = time.Hour
+	require.NoError(t, keeper.SetParams(ctx, params))
+
+	owner := testutil.AccAddress(t)
+
+	cert := testutil.Certificate(t, owner)
+	cert1 := testutil.Certificate(t, owner)
+	cert2 := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	id := types.CertID{Owner: owner, Serial: cert.Serial}
+
+	nid, err := keeper.RotateCertificate(ctx, id, cert1.PEM.Cert, cert1.PEM.Pub)
+	require.NoError(t, err)
+	require.Equal(t, cert1.Serial.String(), nid.Serial.String())
+
+	testutil.EnsureEvent(t, ctx.EventManager().ABCIEvents(), &types.EventCertificateRotated{
+		Owner:     owner.String(),
+		OldSerial: cert.Serial.String(),
+		NewSerial: cert1.Serial.String(),
+		RevokeAt:  ctx.BlockTime().Add(time.Hour),
+	})
+
+	// certificate can be rotated only once
+	_, err = keeper.RotateCertificate(ctx, id, cert2.PEM.Cert, cert2.PEM.Pub)
+	require.ErrorIs(t, err, types.ErrCertificateRotating)
+
+	// both certificates are valid during grace period
+	revoked, err := keeper.RevokeRotatedCertificates(ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour-time.Second)), 10)
+	require.NoError(t, err)
+	require.Zero(t, revoked)
+
+	resp, exists := keeper.GetCertificateByID(ctx, id)
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert, resp, types.CertificateValid)
+
+	revoked, err = keeper.RevokeRotatedCertificates(ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour)), 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, revoked)
+
+	resp, exists = keeper.GetCertificateByID(ctx, id)
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert, resp, types.CertificateRevoked)
+
+	resp, exists = keeper.GetCertificateByID(ctx, nid)
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert1, resp, types.CertificateValid)
+
+	// revoked certificate cannot be rotated
+	_, err = keeper.RotateCertificate(ctx, id, cert2.PEM.Cert, cert2.PEM.Pub)
+	require.ErrorIs(t, err, types.ErrCertificateNotValid)
+}
+
+func TestCertKeeperRotateRevoked(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+
+	cert := testutil.Certificate(t, owner)
+	cert1 := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	id := types.CertID{Owner: owner, Serial: cert.Serial}
+
+	_, err = keeper.RotateCertificate(ctx, id, cert1.PEM.Cert, cert1.PEM.Pub)
+	require.NoError(t, err)
+
+	// revoking rotated certificate within grace period drops the schedule
+	require.NoError(t, keeper.RevokeCertificate(ctx, id))
+
+	count := 0
+	keeper.WithCertificateRotations(ctx, func(_ types.CertID, _ time.Time) bool {
+		count++
+		return false
+	})
+	require.Zero(t, count)
+}
+
+func TestCertKeeperRevocations(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+
+	cert := testutil.Certificate(t, owner)
+	cert1 := testutil.Certificate(t, owner)
+	cert2 := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	err = keeper.CreateCertificate(ctx, owner, cert1.PEM.Cert, cert1.PEM.Pub)
+	require.NoError(t, err)
+
+	err = keeper.CreateCertificate(ctx, owner, cert2.PEM.Cert, cert2.PEM.Pub)
+	require.NoError(t, err)
+
+	err = keeper.RevokeCertificate(ctx.WithBlockHeight(10), types.CertID{Owner: owner, Serial: cert.Serial})
+	require.NoError(t, err)
+
+	err = keeper.RevokeCertificate(ctx.WithBlockHeight(20), types.CertID{Owner: owner, Serial: cert1.Serial})
+	require.NoError(t, err)
+
+	var heights []int64
+	keeper.WithCertificatesRevoked(ctx, 11, func(id types.CertID, height int64, _ time.Time) bool {
+		require.Equal(t, cert1.Serial.String(), id.Serial.String())
+		heights = append(heights, height)
+		return false
+	})
+	require.Equal(t, []int64{20}, heights)
+
+	querier := keeper.Querier()
+
+	res, err := querier.CertificateRevocations(ctx.WithBlockHeight(30), &types.QueryCertificateRevocationsRequest{
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), res.Height)
+	require.Len(t, res.Revocations, 1)
+	require.Equal(t, cert.Serial.String(), res.Revocations[0].Serial)
+	require.Equal(t, int64(10), res.Revocations[0].Height)
+	require.NotEmpty(t, res.Pagination.NextKey)
+
+	res, err = querier.CertificateRevocations(ctx.WithBlockHeight(30), &types.QueryCertificateRevocationsRequest{
+		Pagination: &sdkquery.PageRequest{Key: res.Pagination.NextKey, Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Revocations, 1)
+	require.Equal(t, cert1.Serial.String(), res.Revocations[0].Serial)
+	require.Empty(t, res.Pagination.NextKey)
+}
+
+func TestCertGenesisRevocations(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+	crt := testutil.Certificate(t, owner)
+
+	err := keeper.CreateCertificate(ctx, owner, crt.PEM.Cert, crt.PEM.Pub)
+	require.NoError(t, err)
+
+	err = keeper.RevokeCertificate(ctx.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0)), types.CertID{Owner: owner, Serial: crt.Serial})
+	require.NoError(t, err)
+
+	exported := cert.ExportGenesis(ctx, keeper)
+	require.NoError(t, cert.ValidateGenesis(exported))
+	require.Len(t, exported.Revocations, 1)
+	require.Equal(t, int64(10), exported.Revocations[0].Height)
+
+	// revocation height is preserved when imported at a different height
+	ictx, ikeeper := setupKeeper(t)
+	cert.InitGenesis(ictx.WithBlockHeight(100), ikeeper, exported)
+
+	var heights []int64
+	ikeeper.WithCertificatesRevoked(ictx, 0, func(_ types.CertID, height int64, revokedAt time.Time) bool {
+		heights = append(heights, height)
+		require.Equal(t, time.Unix(1000, 0).UTC(), revokedAt)
+		return false
+	})
+	require.Equal(t, []int64{10}, heights)
+
+	// revoked certificates without revocation record are listed at height 0
+	exported.Revocations = nil
+
+	ictx, ikeeper = setupKeeper(t)
+	cert.InitGenesis(ictx.WithBlockHeight(100), ikeeper, exported)
+
+	heights = nil
+	ikeeper.WithCertificatesRevoked(ictx, 0, func(_ types.CertID, height int64, _ time.Time) bool {
+		heights = append(heights, height)
+		return false
+	})
+	require.Equal(t, []int64{0}, heights)
+}
+
+func certNotAfter(t testing.TB, crt []byte) time.Time {
+	t.Helper()
+
+	block, _ := pem.Decode(crt)
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert.NotAfter
+}
+
 func setupKeeper(t testing.TB) (sdk.Context, keeper.Keeper) {
 	t.Helper()
 
@@ -220,5 +499,5 @@ func setupKeeper(t testing.TB) (sdk.Context, keeper.Keeper) {
 	require.NoError(t, err)
 
 	ctx := sdk.NewContext(ms, tmproto.Header{Time: time.Unix(0, 0)}, false, testutil.Logger(t))
-	return ctx, keeper.NewKeeper(cdc, key)
+	return ctx, keeper.NewKeeper(cdc, key, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 }
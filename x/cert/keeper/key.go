@@ -2,7 +2,9 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
+	"time"
 
 	errorsmod "cosmossdk.io/errors"
 
@@ -23,12 +25,20 @@ const (
 const (
 	CertStateValidPrefixID   = byte(0x01)
 	CertStateRevokedPrefixID = byte(0x02)
+	CertStateExpiredPrefixID = byte(0x03)
 )
 
 var (
 	CertPrefix             = []byte{0x11}
 	CertStateValidPrefix   = []byte{CertStateValidPrefixID}
 	CertStateRevokedPrefix = []byte{CertStateRevokedPrefixID}
+	CertStateExpiredPrefix = []byte{CertStateExpiredPrefixID}
+
+	CertExpiryPrefix     = []byte{0x12}
+	ParamsKey            = []byte{0x13}
+	CertRotationPrefix   = []byte{0x14}
+	CertRevocationPrefix = []byte{0x15}
+	CertRevokedPrefix    = []byte{0x16}
 )
 
 func certStateToPrefix(state types.State) []byte {
@@ -39,6 +49,8 @@ func certStateToPrefix(state types.State) []byte {
 		idx = CertStateValidPrefix
 	case types.CertificateRevoked:
 		idx = CertStateRevokedPrefix
+	case types.CertificateExpired:
+		idx = CertStateExpiredPrefix
 	default:
 		panic("unknown certificate state")
 	}
@@ -194,6 +206,245 @@ func ParseCertKey(from []byte) (types.State, types.CertID, error) {
 	return state, res, nil
 }
 
+// CertificateExpiryKey creates an expiry index key of the format:
+// prefix_bytes | not_after unix seconds (8 bytes big endian) | owner_address_len (1 byte) | owner_address_bytes | serial length (1 byte) | serial_bytes
+func CertificateExpiryKey(notAfter time.Time, id types.CertID) ([]byte, error) {
+	return certTimeKey(CertExpiryPrefix, notAfter, id)
+}
+
+func MustCertificateExpiryKey(notAfter time.Time, id types.CertID) []byte {
+	key, err := CertificateExpiryKey(notAfter, id)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// ParseCertExpiryKey parse certificate expiry index key into expiry time and id
+// format <0x12><not after><add len><add bytes><serial length><serial bytes>
+func ParseCertExpiryKey(from []byte) (time.Time, types.CertID, error) {
+	return parseCertTimeKey(CertExpiryPrefix, from)
+}
+
+// CertificateRotationKey creates a store key of the rotated certificate of the format:
+// prefix_bytes | owner_address_len (1 byte) | owner_address_bytes | serial length (1 byte) | serial_bytes
+func CertificateRotationKey(id types.CertID) ([]byte, error) {
+	if id.Owner.Empty() {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "owner address is empty")
+	}
+
+	addr, err := address.LengthPrefix(id.Owner.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialPrefix(id.Serial.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(CertRotationPrefix)
+	if _, err := buf.Write(addr); err != nil {
+		return nil, err
+	}
+
+	if _, err := buf.Write(serial); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func MustCertificateRotationKey(id types.CertID) []byte {
+	key, err := CertificateRotationKey(id)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// CertificateTimeValue encodes time value of rotation and revoked certificates index as unix seconds (8 bytes big endian)
+func CertificateTimeValue(tm time.Time) []byte {
+	return certTimePrefix(nil, tm)
+}
+
+// ParseCertificateTimeValue decodes time value of rotation and revoked certificates index
+func ParseCertificateTimeValue(from []byte) (time.Time, error) {
+	if err := validation.KeyLength(from, 8); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(int64(binary.BigEndian.Uint64(from)), 0).UTC(), nil // nolint: gosec
+}
+
+// CertificateRevocationKey creates a revocation schedule key of the format:
+// prefix_bytes | revoke at unix seconds (8 bytes big endian) | owner_address_len (1 byte) | owner_address_bytes | serial length (1 byte) | serial_bytes
+func CertificateRevocationKey(revokeAt time.Time, id types.CertID) ([]byte, error) {
+	return certTimeKey(CertRevocationPrefix, revokeAt, id)
+}
+
+func MustCertificateRevocationKey(revokeAt time.Time, id types.CertID) []byte {
+	key, err := CertificateRevocationKey(revokeAt, id)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// ParseCertRevocationKey parse certificate revocation schedule key into revocation time and id
+// format <0x15><revoke at><add len><add bytes><serial length><serial bytes>
+func ParseCertRevocationKey(from []byte) (time.Time, types.CertID, error) {
+	return parseCertTimeKey(CertRevocationPrefix, from)
+}
+
+// CertificateRevokedKey creates a revoked certificates index key of the format:
+// prefix_bytes | revocation height (8 bytes big endian) | owner_address_len (1 byte) | owner_address_bytes | serial length (1 byte) | serial_bytes
+func CertificateRevokedKey(height int64, id types.CertID) ([]byte, error) {
+	return certSeqKey(CertRevokedPrefix, clampSeq(height), id)
+}
+
+func MustCertificateRevokedKey(height int64, id types.CertID) []byte {
+	key, err := CertificateRevokedKey(height, id)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// ParseCertRevokedKey parse revoked certificates index key into revocation height and id
+// format <0x16><height><add len><add bytes><serial length><serial bytes>
+func ParseCertRevokedKey(from []byte) (int64, types.CertID, error) {
+	height, id, err := parseCertSeqKey(CertRevokedPrefix, from)
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	return int64(height), id, nil // nolint: gosec
+}
+
+func certTimeKey(prefix []byte, tm time.Time, id types.CertID) ([]byte, error) {
+	return certSeqKey(prefix, clampSeq(tm.Unix()), id)
+}
+
+func parseCertTimeKey(prefix []byte, from []byte) (time.Time, types.CertID, error) {
+	ts, id, err := parseCertSeqKey(prefix, from)
+	if err != nil {
+		return time.Time{}, types.CertID{}, err
+	}
+
+	return time.Unix(int64(ts), 0).UTC(), id, nil // nolint: gosec
+}
+
+// certTimePrefix returns prefix of time ordered index for given time.
+// Times before unix epoch are clamped to it.
+func certTimePrefix(prefix []byte, tm time.Time) []byte {
+	return certSeqPrefix(prefix, clampSeq(tm.Unix()))
+}
+
+func certSeqKey(prefix []byte, seq uint64, id types.CertID) ([]byte, error) {
+	if id.Owner.Empty() {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "owner address is empty")
+	}
+
+	addr, err := address.LengthPrefix(id.Owner.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialPrefix(id.Serial.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(certSeqPrefix(prefix, seq))
+	if _, err := buf.Write(addr); err != nil {
+		return nil, err
+	}
+
+	if _, err := buf.Write(serial); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func parseCertSeqKey(prefix []byte, from []byte) (uint64, types.CertID, error) {
+	res := types.CertID{
+		Serial: *big.NewInt(0),
+	}
+
+	err := validation.KeyAtLeastLength(from, len(prefix)+8)
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	// skip prefix
+	from = from[len(prefix):]
+	seq := binary.BigEndian.Uint64(from[:8])
+	from = from[8:]
+
+	// parse address length
+	err = validation.KeyAtLeastLength(from, 1)
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	addrLen := from[0]
+	from = from[1:]
+
+	// parse address
+	err = validation.KeyAtLeastLength(from, int(addrLen))
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	addr := from[:addrLen]
+	err = sdk.VerifyAddressFormat(addr)
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	// parse serial length
+	from = from[addrLen:]
+	err = validation.KeyAtLeastLength(from, 1)
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	serialLen := from[0]
+
+	// parse serial
+	from = from[1:]
+	err = validation.KeyLength(from, int(serialLen))
+	if err != nil {
+		return 0, types.CertID{}, err
+	}
+
+	res.Owner = sdk.AccAddress(addr)
+	res.Serial.SetBytes(from)
+
+	return seq, res, nil
+}
+
+func certSeqPrefix(prefix []byte, seq uint64) []byte {
+	res := make([]byte, len(prefix), len(prefix)+8)
+	copy(res, prefix)
+
+	return binary.BigEndian.AppendUint64(res, seq)
+}
+
+func clampSeq(val int64) uint64 {
+	if val < 0 {
+		return 0
+	}
+
+	return uint64(val)
+}
+
 // CertificateKeyLegacy creates a store key of the format:
 // prefix_bytes | owner_address_len (1 byte) | owner_address_bytes | serial_bytes
 func CertificateKeyLegacy(id types.CertID) []byte {
@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/cert/v1"
+)
+
+// RotateCertificate publishes replacement of the valid certificate with given id and schedules
+// the certificate for revocation once rotation grace period has passed.
+// It returns id of the replacement certificate.
+func (k keeper) RotateCertificate(ctx sdk.Context, id types.CertID, crt []byte, pubkey []byte) (types.CertID, error) {
+	store := ctx.KVStore(k.skey)
+
+	key := k.findCertificate(ctx, id)
+	if len(key) == 0 {
+		return types.CertID{}, types.ErrCertificateNotFound
+	}
+
+	var cert types.Certificate
+	k.cdc.MustUnmarshal(store.Get(key), &cert)
+
+	if cert.State != types.CertificateValid {
+		return types.CertID{}, fmt.Errorf("%w: certificate is %s", types.ErrCertificateNotValid, cert.State)
+	}
+
+	if _, rotating := k.getCertificateRotation(ctx, id); rotating {
+		return types.CertID{}, types.ErrCertificateRotating
+	}
+
+	ncert, err := types.ParseAndValidateCertificate(id.Owner, crt, pubkey)
+	if err != nil {
+		return types.CertID{}, err
+	}
+
+	nid := types.CertID{
+		Owner:  id.Owner,
+		Serial: *ncert.SerialNumber,
+	}
+
+	if err := k.CreateCertificate(ctx, id.Owner, crt, pubkey); err != nil {
+		return types.CertID{}, err
+	}
+
+	revokeAt := ctx.BlockTime().Add(k.GetParams(ctx).RotationGracePeriod)
+
+	if err := k.setCertificateRotation(ctx, id, revokeAt); err != nil {
+		return types.CertID{}, err
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&types.EventCertificateRotated{
+			Owner:     id.Owner.String(),
+			OldSerial: id.Serial.String(),
+			NewSerial: nid.Serial.String(),
+			RevokeAt:  revokeAt,
+		},
+	)
+	if err != nil {
+		return types.CertID{}, err
+	}
+
+	return nid, nil
+}
+
+// RevokeRotatedCertificates revokes up to limit rotated certificates which grace period has passed.
+// It returns number of processed rotations.
+func (k keeper) RevokeRotatedCertificates(ctx sdk.Context, limit int) (int, error) {
+	store := ctx.KVStore(k.skey)
+
+	// include certificates scheduled for revocation at current block time
+	iter := store.Iterator(CertRevocationPrefix, certTimePrefix(CertRevocationPrefix, ctx.BlockTime().Add(time.Second)))
+
+	var ids []types.CertID
+
+	for ; iter.Valid() && len(ids) < limit; iter.Next() {
+		_, id, err := ParseCertRevocationKey(iter.Key())
+		if err != nil {
+			_ = iter.Close()
+			return 0, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	_ = iter.Close()
+
+	for _, id := range ids {
+		if err := k.RevokeCertificate(ctx, id); err != nil {
+			// drop the schedule so it does not stall following rotations
+			ctx.Logger().Error("failed to revoke rotated certificate", "owner", id.Owner.String(), "serial", id.Serial.String(), "error", err)
+			k.deleteCertificateRotation(ctx, id)
+		}
+	}
+
+	return len(ids), nil
+}
+
+// WithCertificateRotations iterates certificates scheduled for revocation after rotation
+func (k keeper) WithCertificateRotations(ctx sdk.Context, fn func(id types.CertID, revokeAt time.Time) bool) {
+	store := ctx.KVStore(k.skey)
+	iter := storetypes.KVStorePrefixIterator(store, CertRevocationPrefix)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		revokeAt, id, err := ParseCertRevocationKey(iter.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if stop := fn(id, revokeAt); stop {
+			break
+		}
+	}
+}
+
+func (k keeper) getCertificateRotation(ctx sdk.Context, id types.CertID) (time.Time, bool) {
+	store := ctx.KVStore(k.skey)
+
+	buf := store.Get(MustCertificateRotationKey(id))
+	if buf == nil {
+		return time.Time{}, false
+	}
+
+	revokeAt, err := ParseCertificateTimeValue(buf)
+	if err != nil {
+		panic(err)
+	}
+
+	return revokeAt, true
+}
+
+func (k keeper) setCertificateRotation(ctx sdk.Context, id types.CertID, revokeAt time.Time) error {
+	store := ctx.KVStore(k.skey)
+
+	rkey, err := CertificateRevocationKey(revokeAt, id)
+	if err != nil {
+		return err
+	}
+
+	key, err := CertificateRotationKey(id)
+	if err != nil {
+		return err
+	}
+
+	store.Set(key, CertificateTimeValue(revokeAt))
+	store.Set(rkey, []byte{})
+
+	return nil
+}
+
+func (k keeper) deleteCertificateRotation(ctx sdk.Context, id types.CertID) {
+	revokeAt, found := k.getCertificateRotation(ctx, id)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.skey)
+
+	store.Delete(MustCertificateRotationKey(id))
+	store.Delete(MustCertificateRevocationKey(revokeAt, id))
+}
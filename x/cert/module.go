@@ -35,6 +35,9 @@ var (
 	_ module.AppModuleSimulation = AppModule{}
 )
 
+// maxRevocationsPerBlock bounds number of rotated certificates revoked within single block
+const maxRevocationsPerBlock = 100
+
 // AppModuleBasic defines the basic application module used by the cert module.
 type AppModuleBasic struct {
 	cdc codec.Codec
@@ -135,10 +138,11 @@ func (am AppModule) BeginBlock(_ context.Context) error {
 	return nil
 }
 
-// EndBlock returns the end blocker for the cert module. It returns no validator
-// updates.
-func (am AppModule) EndBlock(_ context.Context) error {
-	return nil
+// EndBlock returns the end blocker for the cert module. It revokes rotated certificates
+// which grace period has passed. It returns no validator updates.
+func (am AppModule) EndBlock(ctx context.Context) error {
+	_, err := am.keeper.RevokeRotatedCertificates(sdk.UnwrapSDKContext(ctx), maxRevocationsPerBlock)
+	return err
 }
 
 // InitGenesis performs genesis initialization for the cert module. It returns
@@ -159,7 +163,7 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
 func (am AppModule) ConsensusVersion() uint64 {
-	return 4
+	return 5
 }
 
 // AppModuleSimulation functions
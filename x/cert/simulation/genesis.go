@@ -7,7 +7,9 @@ import (
 )
 
 func RandomizedGenState(simState *module.SimulationState) {
-	deploymentGenesis := &types.GenesisState{}
+	deploymentGenesis := &types.GenesisState{
+		Params: types.DefaultParams(),
+	}
 
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(deploymentGenesis)
 }
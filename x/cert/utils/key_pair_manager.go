@@ -16,7 +16,6 @@ import (
 	"io"
 	"math/big"
 	"net"
-	"os"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
@@ -51,10 +50,36 @@ type keyPairManager struct {
 	addr           sdk.AccAddress
 	passwordBytes  []byte
 	passwordLegacy []byte
-	homeDir        string
+	storage        KeyStorage
 }
 
-func NewKeyPairManager(cctx sdkclient.Context, fromAddress sdk.AccAddress) (KeyPairManager, error) {
+// KeyPairManagerOption configures KeyPairManager
+type KeyPairManagerOption func(*keyPairManager)
+
+// WithKeyStorage sets storage of the key pair. Default is file storage in the client home directory.
+// Private key in storage implementing PassphraseKeyStorage is encrypted with its passphrase,
+// otherwise password is derived by signing with the account key.
+func WithKeyStorage(storage KeyStorage) KeyPairManagerOption {
+	return func(kpm *keyPairManager) {
+		kpm.storage = storage
+	}
+}
+
+func NewKeyPairManager(cctx sdkclient.Context, fromAddress sdk.AccAddress, opts ...KeyPairManagerOption) (KeyPairManager, error) {
+	kpm := &keyPairManager{
+		addr:    fromAddress,
+		storage: NewFileKeyStorage(cctx.HomeDir),
+	}
+
+	for _, opt := range opts {
+		opt(kpm)
+	}
+
+	if pstorage, valid := kpm.storage.(PassphraseKeyStorage); valid {
+		kpm.passwordBytes = pstorage.Passphrase()
+		return kpm, nil
+	}
+
 	sig, _, err := cctx.Keyring.SignByAddress(fromAddress, []byte(fromAddress.String()), signing.SignMode_SIGN_MODE_DIRECT)
 	if err != nil {
 		return nil, err
@@ -65,16 +90,10 @@ func NewKeyPairManager(cctx sdkclient.Context, fromAddress sdk.AccAddress) (KeyP
 	// if test or file keyring used it will allow to decode old private keys for the mTLS cert
 	sigLegacy, _, _ := cctx.Keyring.SignByAddress(fromAddress, fromAddress.Bytes(), signing.SignMode_SIGN_MODE_DIRECT)
 
-	return &keyPairManager{
-		addr:           fromAddress,
-		passwordBytes:  sig,
-		passwordLegacy: sigLegacy,
-		homeDir:        cctx.HomeDir,
-	}, nil
-}
+	kpm.passwordBytes = sig
+	kpm.passwordLegacy = sigLegacy
 
-func (kpm *keyPairManager) getKeyPath() string {
-	return kpm.homeDir + "/" + kpm.addr.String() + ".pem"
+	return kpm, nil
 }
 
 func (kpm *keyPairManager) ReadX509KeyPair(fin ...io.Reader) (*x509.Certificate, tls.Certificate, error) {
@@ -101,33 +120,17 @@ func (kpm *keyPairManager) ReadX509KeyPair(fin ...io.Reader) (*x509.Certificate,
 }
 
 func (kpm *keyPairManager) KeyExists() (bool, error) {
-	_, err := os.Stat(kpm.getKeyPath())
-	if err == nil {
-		return true, nil
-	}
-
-	if os.IsNotExist(err) {
-		return false, nil
-	}
-
-	return false, err
+	return kpm.storage.Exists(kpm.addr)
 }
 
 func (kpm *keyPairManager) Generate(notBefore, notAfter time.Time, domains []string) error {
-	var err error
-	var pemOut *os.File
-	if pemOut, err = os.OpenFile(kpm.getKeyPath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
-		return err
-	}
+	pemOut := &bytes.Buffer{}
 
-	err = kpm.generateImpl(notBefore, notAfter, domains, pemOut)
-
-	closeErr := pemOut.Close()
-	if closeErr != nil {
-		return closeErr
+	if err := kpm.generateImpl(notBefore, notAfter, domains, pemOut); err != nil {
+		return err
 	}
 
-	return err
+	return kpm.storage.Save(kpm.addr, pemOut.Bytes())
 }
 
 func (kpm *keyPairManager) generateImpl(notBefore, notAfter time.Time, domains []string, fout io.Writer) error {
@@ -216,7 +219,6 @@ func (kpm *keyPairManager) generateImpl(notBefore, notAfter time.Time, domains [
 
 func (kpm *keyPairManager) Read(fin ...io.Reader) ([]byte, []byte, []byte, error) {
 	var pemIn io.Reader
-	var closeMe io.ReadCloser
 
 	if len(fin) != 0 {
 		if len(fin) != 1 {
@@ -226,24 +228,15 @@ func (kpm *keyPairManager) Read(fin ...io.Reader) ([]byte, []byte, []byte, error
 	}
 
 	if pemIn == nil {
-		fopen, err := os.OpenFile(kpm.getKeyPath(), os.O_RDONLY, 0x0)
+		data, err := kpm.storage.Load(kpm.addr)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("could not open certificate PEM file: %w", err)
+			return nil, nil, nil, err
 		}
-		closeMe = fopen
-		pemIn = fopen
-	}
 
-	cert, privKey, pubKey, err := kpm.readImpl(pemIn)
-
-	if closeMe != nil {
-		closeErr := closeMe.Close()
-		if closeErr != nil {
-			return nil, nil, nil, fmt.Errorf("could not close PEM file: %w", closeErr)
-		}
+		pemIn = bytes.NewReader(data)
 	}
 
-	return cert, privKey, pubKey, err
+	return kpm.readImpl(pemIn)
 }
 
 func (kpm *keyPairManager) readImpl(fin io.Reader) ([]byte, []byte, []byte, error) {
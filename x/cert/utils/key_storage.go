@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/99designs/keyring"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	certerrors "pkg.akt.dev/node/v2/x/cert/errors"
+)
+
+const (
+	// DefaultKeyringService is the OS keyring service certificates are stored under
+	DefaultKeyringService = "akash-cert"
+)
+
+var (
+	errEmptyPassphrase = fmt.Errorf("%w: passphrase must not be empty", certerrors.ErrCertificate)
+	errKeyNotFound     = fmt.Errorf("%w: key pair not found", certerrors.ErrCertificate)
+)
+
+// KeyStorage persists PEM encoded certificate and private key of an account
+type KeyStorage interface {
+	Exists(addr sdk.AccAddress) (bool, error)
+	Load(addr sdk.AccAddress) ([]byte, error)
+	Save(addr sdk.AccAddress, data []byte) error
+}
+
+// PassphraseKeyStorage is implemented by storages which private keys are encrypted
+// with an operator supplied passphrase instead of one derived with the account keyring.
+// Such storages do not require the account key to sign, so they work with hardware
+// wallets as well.
+type PassphraseKeyStorage interface {
+	KeyStorage
+	Passphrase() []byte
+}
+
+type fileKeyStorage struct {
+	dir string
+}
+
+var _ KeyStorage = (*fileKeyStorage)(nil)
+
+// NewFileKeyStorage returns storage keeping key pair of each account in <dir>/<address>.pem
+func NewFileKeyStorage(dir string) KeyStorage {
+	return &fileKeyStorage{
+		dir: dir,
+	}
+}
+
+func (s *fileKeyStorage) path(addr sdk.AccAddress) string {
+	return filepath.Join(s.dir, addr.String()+".pem")
+}
+
+func (s *fileKeyStorage) Exists(addr sdk.AccAddress) (bool, error) {
+	return fileExists(s.path(addr))
+}
+
+func (s *fileKeyStorage) Load(addr sdk.AccAddress) ([]byte, error) {
+	return loadFile(s.path(addr))
+}
+
+func (s *fileKeyStorage) Save(addr sdk.AccAddress, data []byte) error {
+	return os.WriteFile(s.path(addr), data, 0600)
+}
+
+type encryptedPEMKeyStorage struct {
+	path       string
+	passphrase []byte
+}
+
+var _ PassphraseKeyStorage = (*encryptedPEMKeyStorage)(nil)
+
+// NewEncryptedPEMKeyStorage returns storage keeping key pair in the PEM file at path,
+// with private key encrypted as PKCS#8 using given passphrase
+func NewEncryptedPEMKeyStorage(path string, passphrase []byte) (PassphraseKeyStorage, error) {
+	if len(passphrase) == 0 {
+		return nil, errEmptyPassphrase
+	}
+
+	return &encryptedPEMKeyStorage{
+		path:       path,
+		passphrase: passphrase,
+	}, nil
+}
+
+func (s *encryptedPEMKeyStorage) Exists(_ sdk.AccAddress) (bool, error) {
+	return fileExists(s.path)
+}
+
+func (s *encryptedPEMKeyStorage) Load(_ sdk.AccAddress) ([]byte, error) {
+	return loadFile(s.path)
+}
+
+func (s *encryptedPEMKeyStorage) Save(_ sdk.AccAddress, data []byte) error {
+	return os.WriteFile(s.path, data, 0600)
+}
+
+func (s *encryptedPEMKeyStorage) Passphrase() []byte {
+	return s.passphrase
+}
+
+type osKeyringStorage struct {
+	kr keyring.Keyring
+}
+
+var _ KeyStorage = (*osKeyringStorage)(nil)
+
+// NewOSKeyringStorage returns storage keeping key pairs in the OS keyring
+// (macOS Keychain, Windows Credential Manager, Secret Service or KWallet) under given service
+func NewOSKeyringStorage(service string) (KeyStorage, error) {
+	kr, err := keyring.Open(keyring.Config{
+		ServiceName: service,
+		AllowedBackends: []keyring.BackendType{
+			keyring.KeychainBackend,
+			keyring.WinCredBackend,
+			keyring.SecretServiceBackend,
+			keyring.KWalletBackend,
+		},
+		KeychainTrustApplication: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not open OS keyring: %w", err)
+	}
+
+	return &osKeyringStorage{
+		kr: kr,
+	}, nil
+}
+
+func (s *osKeyringStorage) Exists(addr sdk.AccAddress) (bool, error) {
+	_, err := s.kr.Get(addr.String())
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, keyring.ErrKeyNotFound) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+func (s *osKeyringStorage) Load(addr sdk.AccAddress) ([]byte, error) {
+	item, err := s.kr.Get(addr.String())
+	if err != nil {
+		if errors.Is(err, keyring.ErrKeyNotFound) {
+			return nil, errKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	return item.Data, nil
+}
+
+func (s *osKeyringStorage) Save(addr sdk.AccAddress, data []byte) error {
+	return s.kr.Set(keyring.Item{
+		Key:         addr.String(),
+		Data:        data,
+		Label:       "akash certificate " + addr.String(),
+		Description: "akash mTLS certificate and private key",
+	})
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+func loadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not open certificate PEM file: %w", err)
+	}
+
+	return data, nil
+}
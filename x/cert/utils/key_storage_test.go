@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+
+	"pkg.akt.dev/go/testutil"
+)
+
+func TestEncryptedPEMKeyStorage(t *testing.T) {
+	addr := testutil.AccAddress(t)
+	path := filepath.Join(t.TempDir(), "client.pem")
+
+	_, err := NewEncryptedPEMKeyStorage(path, nil)
+	require.ErrorIs(t, err, errEmptyPassphrase)
+
+	storage, err := NewEncryptedPEMKeyStorage(path, []byte("passphrase"))
+	require.NoError(t, err)
+
+	// account keyring is not used with passphrase storage
+	kpm, err := NewKeyPairManager(sdkclient.Context{}, addr, WithKeyStorage(storage))
+	require.NoError(t, err)
+
+	exists, err := kpm.KeyExists()
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	now := time.Now().UTC()
+	require.NoError(t, kpm.Generate(now, now.Add(time.Hour), nil))
+
+	exists, err = kpm.KeyExists()
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	x509cert, _, err := kpm.ReadX509KeyPair()
+	require.NoError(t, err)
+	require.Equal(t, addr.String(), x509cert.Subject.CommonName)
+
+	// wrong passphrase must not decrypt private key
+	storage, err = NewEncryptedPEMKeyStorage(path, []byte("wrong"))
+	require.NoError(t, err)
+
+	kpm, err = NewKeyPairManager(sdkclient.Context{}, addr, WithKeyStorage(storage))
+	require.NoError(t, err)
+
+	_, _, err = kpm.ReadX509KeyPair()
+	require.Error(t, err)
+}
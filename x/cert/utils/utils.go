@@ -14,9 +14,10 @@ import (
 	ctypes "pkg.akt.dev/go/node/cert/v1"
 )
 
-// LoadAndQueryCertificateForAccount wraps LoadAndQueryPEMForAccount and tls.X509KeyPair
-func LoadAndQueryCertificateForAccount(ctx context.Context, cctx client.Context, fin io.Reader) (tls.Certificate, error) {
-	kpm, err := NewKeyPairManager(cctx, cctx.FromAddress)
+// LoadAndQueryCertificateForAccount wraps LoadAndQueryPEMForAccount and tls.X509KeyPair.
+// Key pair is read from fin if set, otherwise from the key storage set by opts.
+func LoadAndQueryCertificateForAccount(ctx context.Context, cctx client.Context, fin io.Reader, opts ...KeyPairManagerOption) (tls.Certificate, error) {
+	kpm, err := NewKeyPairManager(cctx, cctx.FromAddress, opts...)
 	if err != nil {
 		return tls.Certificate{}, err
 	}
@@ -60,6 +60,29 @@ func ValidateGenesis(data *types.GenesisState) error {
 		pmap[payment.ID] = payment
 	}
 
+	rmap := make(map[eid.Payment]struct{}, len(data.Refunds))
+
+	for idx, refund := range data.Refunds {
+		payment, found := pmap[refund.ID]
+		if !found {
+			return fmt.Errorf("%w: no payment %s for refund (idx %v)", emodule.ErrPaymentNotFound, refund.ID, idx)
+		}
+
+		if payment.State.State == etypes.StateClosed {
+			return fmt.Errorf("%w: refund of closed payment %s (idx %v)", emodule.ErrPaymentClosed, refund.ID, idx)
+		}
+
+		if refund.Amount.Denom != payment.State.Rate.Denom || !refund.Amount.IsPositive() {
+			return fmt.Errorf("%w: invalid refund amount %s for payment %s (idx %v)", emodule.ErrInvalidPayment, refund.Amount, refund.ID, idx)
+		}
+
+		if _, exists := rmap[refund.ID]; exists {
+			return fmt.Errorf("%w: duplicate refund for payment %s (idx %v)", emodule.ErrInvalidPayment, refund.ID, idx)
+		}
+
+		rmap[refund.ID] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -77,6 +100,12 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *types.GenesisState
 			panic(fmt.Sprintf("error saving payment: %s", err.Error()))
 		}
 	}
+	for _, refund := range data.Refunds {
+		err := keeper.SavePaymentRefund(ctx, refund.ID, refund.Amount)
+		if err != nil {
+			panic(fmt.Sprintf("error saving payment refund: %s", err.Error()))
+		}
+	}
 }
 
 // ExportGenesis returns genesis state as raw bytes for the provider module
@@ -93,6 +122,14 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		return false
 	})
 
+	k.WithPaymentRefunds(ctx, func(id eid.Payment, amount sdk.DecCoin) bool {
+		state.Refunds = append(state.Refunds, types.PaymentRefund{
+			ID:     id,
+			Amount: amount,
+		})
+		return false
+	})
+
 	return state
 }
 
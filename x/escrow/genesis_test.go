@@ -0,0 +1,61 @@
+package escrow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	emodule "pkg.akt.dev/go/node/escrow/module"
+	etypes "pkg.akt.dev/go/node/escrow/types/v1"
+	types "pkg.akt.dev/go/node/escrow/v1"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/testutil/state"
+	"pkg.akt.dev/node/v2/x/escrow"
+)
+
+func TestGenesisPaymentRefunds(t *testing.T) {
+	ssuite := state.SetupTestSuite(t)
+	ctx := ssuite.Context()
+	ekeeper := ssuite.EscrowKeeper()
+
+	lid := testutil.LeaseID(t)
+	aid := lid.DeploymentID().ToEscrowAccountID()
+	pid := lid.ToEscrowPaymentID()
+
+	owner := testutil.AccAddress(t)
+	amt := testutil.ACTCoin(t, 1000)
+	rate := sdk.NewDecCoinFromCoin(sdk.NewCoin("uact", sdkmath.NewInt(10)))
+
+	ssuite.MockBMEForDeposit(owner, amt)
+	require.NoError(t, ekeeper.AccountCreate(ctx, aid, owner, []etypes.Depositor{{
+		Owner:   owner.String(),
+		Height:  ctx.BlockHeight(),
+		Balance: sdk.NewDecCoinFromCoin(amt),
+	}}))
+	require.NoError(t, ekeeper.PaymentCreate(ctx, pid, testutil.AccAddress(t), rate))
+
+	refund := sdk.NewDecCoin("uact", sdkmath.NewInt(25))
+	require.NoError(t, ekeeper.PaymentRefund(ctx, pid, refund))
+
+	exported := escrow.ExportGenesis(ctx, ekeeper)
+	require.Equal(t, []types.PaymentRefund{{ID: pid, Amount: refund}}, exported.Refunds)
+	require.NoError(t, escrow.ValidateGenesis(exported))
+
+	isuite := state.SetupTestSuite(t)
+	escrow.InitGenesis(isuite.Context(), isuite.EscrowKeeper(), exported)
+
+	reexported := escrow.ExportGenesis(isuite.Context(), isuite.EscrowKeeper())
+	require.Equal(t, exported.Refunds, reexported.Refunds)
+
+	// refund must reference a known payment in the payment denomination
+	invalid := *exported
+	invalid.Refunds = []types.PaymentRefund{{ID: pid, Amount: sdk.NewDecCoin("uakt", sdkmath.NewInt(25))}}
+	require.ErrorIs(t, escrow.ValidateGenesis(&invalid), emodule.ErrInvalidPayment)
+
+	invalid.Refunds = []types.PaymentRefund{{ID: testutil.LeaseID(t).ToEscrowPaymentID(), Amount: refund}}
+	require.ErrorIs(t, escrow.ValidateGenesis(&invalid), emodule.ErrPaymentNotFound)
+}
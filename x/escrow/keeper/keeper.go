@@ -46,16 +46,20 @@ type Keeper interface {
 	PaymentCreate(ctx sdk.Context, id escrowid.Payment, owner sdk.AccAddress, rate sdk.DecCoin) error
 	PaymentWithdraw(ctx sdk.Context, id escrowid.Payment) error
 	PaymentClose(ctx sdk.Context, id escrowid.Payment) error
+	PaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) error
 	GetAccount(ctx sdk.Context, id escrowid.Account) (etypes.Account, error)
 	GetPayment(ctx sdk.Context, id escrowid.Payment) (etypes.Payment, error)
 	AddOnAccountClosedHook(AccountHook) Keeper
 	AddOnPaymentClosedHook(PaymentHook) Keeper
 	WithAccounts(sdk.Context, func(etypes.Account) bool)
+	WithAccountsForState(sdk.Context, etypes.State, func(etypes.Account) bool)
 	WithPayments(sdk.Context, func(etypes.Payment) bool)
+	WithPaymentRefunds(sdk.Context, func(escrowid.Payment, sdk.DecCoin) bool)
 	SaveAccount(sdk.Context, etypes.Account) error
 	SavePayment(sdk.Context, etypes.Payment) error
 	SaveAccountRaw(sdk.Context, etypes.Account) error
 	SavePaymentRaw(sdk.Context, etypes.Payment) error
+	SavePaymentRefund(sdk.Context, escrowid.Payment, sdk.DecCoin) error
 	GetAccountPayments(ctx sdk.Context, id escrowid.Account, states []etypes.State) []etypes.Payment
 	NewQuerier() Querier
 }
@@ -849,6 +853,33 @@ func (k *keeper) PaymentClose(ctx sdk.Context, id escrowid.Payment) error {
 	return nil
 }
 
+// PaymentRefund schedules amount to be returned to the account owner out of the payment
+// earnings. Refund is credited on next payment settlement, before the earnings are
+// withdrawn to the payment owner, and is capped by the settled balance at that time.
+func (k *keeper) PaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) error {
+	pmnt, err := k.getPayment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if pmnt.State.State == etypes.StateClosed {
+		return module.ErrPaymentClosed
+	}
+
+	if amount.Denom != pmnt.State.Rate.Denom {
+		return module.ErrInvalidDenomination
+	}
+
+	if !amount.IsPositive() {
+		return nil
+	}
+
+	pending := k.getPaymentRefund(ctx, id, amount.Denom)
+	k.setPaymentRefund(ctx, id, pending.Add(amount))
+
+	return nil
+}
+
 func (k *keeper) AddOnAccountClosedHook(hook AccountHook) Keeper {
 	k.hooks.onAccountClosed = append(k.hooks.onAccountClosed, hook)
 	return k
@@ -973,8 +1004,17 @@ func (k *keeper) GetAccountPayments(ctx sdk.Context, id escrowid.Account, states
 }
 
 func (k *keeper) WithAccounts(ctx sdk.Context, fn func(etypes.Account) bool) {
+	k.withAccounts(ctx, AccountPrefix, fn)
+}
+
+// WithAccountsForState iterates accounts in given state only
+func (k *keeper) WithAccountsForState(ctx sdk.Context, state etypes.State, fn func(etypes.Account) bool) {
+	k.withAccounts(ctx, BuildAccountsKey(state, nil), fn)
+}
+
+func (k *keeper) withAccounts(ctx sdk.Context, prefix []byte, fn func(etypes.Account) bool) {
 	store := ctx.KVStore(k.skey)
-	iter := storetypes.KVStorePrefixIterator(store, AccountPrefix)
+	iter := storetypes.KVStorePrefixIterator(store, prefix)
 
 	defer func() {
 		_ = iter.Close()
@@ -1019,6 +1059,41 @@ func (k *keeper) WithPayments(ctx sdk.Context, fn func(etypes.Payment) bool) {
 	}
 }
 
+// WithPaymentRefunds iterates pending refunds of payments
+func (k *keeper) WithPaymentRefunds(ctx sdk.Context, fn func(escrowid.Payment, sdk.DecCoin) bool) {
+	store := ctx.KVStore(k.skey)
+	iter := storetypes.KVStorePrefixIterator(store, PaymentRefundPrefix)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		id, err := ParsePaymentRefundKey(iter.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		var amount sdk.DecCoin
+		k.cdc.MustUnmarshal(iter.Value(), &amount)
+
+		if stop := fn(id, amount); stop {
+			break
+		}
+	}
+}
+
+// SavePaymentRefund stores pending refund of the payment as is (used by genesis)
+func (k *keeper) SavePaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) error {
+	if _, err := k.getPayment(ctx, id); err != nil {
+		return err
+	}
+
+	k.setPaymentRefund(ctx, id, amount)
+
+	return nil
+}
+
 func (k *keeper) saveAccount(ctx sdk.Context, obj *account) error {
 	store := ctx.KVStore(k.skey)
 
@@ -1190,6 +1265,10 @@ func (k *keeper) paymentWithdraw(ctx sdk.Context, obj *payment) error {
 		return err
 	}
 
+	if err := k.paymentApplyRefund(ctx, obj); err != nil {
+		return err
+	}
+
 	earnings := sdk.NewCoin(obj.State.Balance.Denom, obj.State.Balance.Amount.TruncateInt())
 
 	if earnings.Amount.IsZero() {
@@ -1208,6 +1287,77 @@ func (k *keeper) paymentWithdraw(ctx sdk.Context, obj *payment) error {
 	return nil
 }
 
+// paymentApplyRefund credits pending refund of the payment to the account owner
+// out of the settled balance. Refund remaining once payment is closed is dropped.
+func (k *keeper) paymentApplyRefund(ctx sdk.Context, obj *payment) error {
+	pending := k.getPaymentRefund(ctx, obj.ID, obj.State.Balance.Denom)
+	if pending.IsZero() {
+		return nil
+	}
+
+	refund := pending.Amount
+	if obj.State.Balance.Amount.LT(refund) {
+		refund = obj.State.Balance.Amount
+	}
+
+	credit := sdk.NewCoin(obj.State.Balance.Denom, refund.TruncateInt())
+
+	if credit.IsPositive() {
+		acc, err := k.getAccount(ctx, obj.ID.Account())
+		if err != nil {
+			return err
+		}
+
+		tenant, err := k.ac.StringToBytes(acc.State.Owner)
+		if err != nil {
+			return err
+		}
+
+		err = k.bkeeper.SendCoinsFromModuleToAccount(ctx, module.ModuleName, tenant, sdk.NewCoins(credit))
+		if err != nil {
+			return err
+		}
+
+		obj.State.Balance = obj.State.Balance.Sub(sdk.NewDecCoinFromCoin(credit))
+		pending = pending.Sub(sdk.NewDecCoinFromCoin(credit))
+		obj.dirty = true
+	}
+
+	if obj.State.State == etypes.StateClosed || !pending.IsPositive() {
+		k.deletePaymentRefund(ctx, obj.ID)
+		return nil
+	}
+
+	k.setPaymentRefund(ctx, obj.ID, pending)
+
+	return nil
+}
+
+func (k *keeper) getPaymentRefund(ctx sdk.Context, id escrowid.Payment, denom string) sdk.DecCoin {
+	store := ctx.KVStore(k.skey)
+
+	res := sdk.NewDecCoin(denom, sdkmath.ZeroInt())
+
+	buf := store.Get(BuildPaymentRefundKey(&id))
+	if buf == nil {
+		return res
+	}
+
+	k.cdc.MustUnmarshal(buf, &res)
+
+	return res
+}
+
+func (k *keeper) setPaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) {
+	store := ctx.KVStore(k.skey)
+	store.Set(BuildPaymentRefundKey(&id), k.cdc.MustMarshal(&amount))
+}
+
+func (k *keeper) deletePaymentRefund(ctx sdk.Context, id escrowid.Payment) {
+	store := ctx.KVStore(k.skey)
+	store.Delete(BuildPaymentRefundKey(&id))
+}
+
 func (acc *account) deductFromBalance(amount sdk.DecCoin) (sdk.DecCoin, bool) {
 	remaining := sdkmath.LegacyZeroDec()
 	remaining.AddMut(amount.Amount)
@@ -23,6 +23,7 @@ var (
 	StateClosedPrefix    = []byte{StateClosedPrefixID}
 	StateOverdrawnPrefix = []byte{StateOverdrawnPrefixID}
 	BmeAccountsPrefix    = []byte{0x14, 0x01}
+	PaymentRefundPrefix  = []byte{0x15, 0x00}
 )
 
 func BuildAccountsKey(state etypes.State, id escrowid.ID) []byte {
@@ -56,6 +57,30 @@ func BuildPaymentsKey(state etypes.State, id escrowid.ID) []byte {
 	return buf.Bytes()
 }
 
+// BuildPaymentRefundKey returns key of the pending refund for given payment
+func BuildPaymentRefundKey(id escrowid.ID) []byte {
+	buf := &bytes.Buffer{}
+	buf.Write(PaymentRefundPrefix)
+	writeId(buf, id)
+
+	return buf.Bytes()
+}
+
+// ParsePaymentRefundKey returns id of the payment given pending refund key belongs to
+func ParsePaymentRefundKey(key []byte) (escrowid.Payment, error) {
+	if !bytes.HasPrefix(key, PaymentRefundPrefix) {
+		return escrowid.Payment{}, emodule.ErrMalformedKey.Wrap("malformed prefix")
+	}
+
+	key = key[len(PaymentRefundPrefix):]
+
+	if len(key) == 0 || key[0] != '/' {
+		return escrowid.Payment{}, emodule.ErrMalformedKey.Wrap("malformed separator")
+	}
+
+	return escrowid.ParsePayment(string(key[1:]))
+}
+
 func stateToPrefix(state etypes.State) []byte {
 	switch state {
 	case etypes.StateOpen:
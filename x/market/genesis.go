@@ -3,7 +3,9 @@ package market
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"cosmossdk.io/collections"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -32,9 +34,7 @@ func DefaultGenesisState() *mvbeta.GenesisState {
 }
 
 // InitGenesis initiate genesis state and return updated validator details
-func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *mvbeta.GenesisState) {
-	k := kpr.(*keeper.Keeper)
-
+func InitGenesis(ctx sdk.Context, k keeper.IKeeper, data *mvbeta.GenesisState) {
 	for _, record := range data.Orders {
 		pk := keys.OrderIDToKey(record.ID)
 		has, err := k.Orders().Has(ctx, pk)
@@ -78,7 +78,41 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *mvbeta.GenesisState)
 		}
 	}
 
-	err := kpr.SetParams(ctx, data.Params)
+	for _, record := range data.LeaseMigrations {
+		value := collections.Join(keys.LeaseIDToKey(record.ID), int64(record.Overlap/time.Second))
+		if err := k.LeaseMigrations().Set(ctx, keys.OrderIDToKey(record.OrderID), value); err != nil {
+			panic(fmt.Errorf("market genesis lease migrations init. order id %s: %w", record.OrderID, err))
+		}
+	}
+
+	for _, record := range data.LeaseMigrationCloses {
+		if err := k.LeaseMigrationCloses().Set(ctx, collections.Join(record.Deadline, keys.LeaseIDToKey(record.ID))); err != nil {
+			panic(fmt.Errorf("market genesis lease migration closes init. lease id %s: %w", record.ID, err))
+		}
+	}
+
+	for _, record := range data.LeaseUptimes {
+		key := collections.Join3(keys.LeaseIDToKey(record.ID), record.Epoch, record.Signer)
+		if err := k.LeaseUptimes().Set(ctx, key, record); err != nil {
+			panic(fmt.Errorf("market genesis lease uptimes init. lease id %s: %w", record.ID, err))
+		}
+	}
+
+	for _, record := range data.LeaseSLASettlements {
+		key := collections.Join(record.Height, collections.Join(keys.LeaseIDToKey(record.ID), record.Epoch))
+		if err := k.LeaseSLASettlements().Set(ctx, key); err != nil {
+			panic(fmt.Errorf("market genesis lease sla settlements init. lease id %s: %w", record.ID, err))
+		}
+	}
+
+	for _, record := range data.LeaseSLARecords {
+		key := collections.Join(record.ID.Provider, collections.Join(keys.LeaseIDToKey(record.ID), record.Epoch))
+		if err := k.LeaseSLARecords().Set(ctx, key, record); err != nil {
+			panic(fmt.Errorf("market genesis lease sla records init. lease id %s: %w", record.ID, err))
+		}
+	}
+
+	err := k.SetParams(ctx, data.Params)
 	if err != nil {
 		panic(err)
 	}
@@ -86,35 +120,99 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *mvbeta.GenesisState)
 
 // ExportGenesis returns genesis state as raw bytes for the market module
 func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *mvbeta.GenesisState {
-	params, err := k.GetParams(ctx)
-	if err != nil {
-		panic(err)
-	}
-
-	var bids mvbeta.Bids
-	var leases mv1.Leases
-	var orders mvbeta.Orders
+	state := ExportGenesisSkeleton(ctx, k)
 
 	k.WithLeases(ctx, func(lease mv1.Lease) bool {
-		leases = append(leases, lease)
+		state.Leases = append(state.Leases, lease)
 		return false
 	})
 
 	k.WithOrders(ctx, func(order mvbeta.Order) bool {
-		orders = append(orders, order)
+		state.Orders = append(state.Orders, order)
 		return false
 	})
 
 	k.WithBids(ctx, func(bid mvbeta.Bid) bool {
-		bids = append(bids, bid)
+		state.Bids = append(state.Bids, bid)
 		return false
 	})
 
+	return state
+}
+
+// ExportGenesisSkeleton returns genesis state of the market module without bids, leases and orders,
+// so they can be exported one by one
+func ExportGenesisSkeleton(ctx sdk.Context, k keeper.IKeeper) *mvbeta.GenesisState {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	var migrations []mv1.LeaseMigration
+	var migrationCloses []mv1.LeaseMigrationClose
+
+	err = k.LeaseMigrations().Walk(ctx, nil, func(key keys.OrderPrimaryKey, value keys.LeaseMigrationValue) (bool, error) {
+		migrations = append(migrations, mv1.LeaseMigration{
+			ID:      keys.KeyToLeaseID(value.K1()),
+			OrderID: keys.KeyToOrderID(key),
+			Overlap: time.Duration(value.K2()) * time.Second,
+		})
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = k.LeaseMigrationCloses().Walk(ctx, nil, func(key keys.LeaseMigrationCloseKey) (bool, error) {
+		migrationCloses = append(migrationCloses, mv1.LeaseMigrationClose{
+			ID:       keys.KeyToLeaseID(key.K2()),
+			Deadline: key.K1(),
+		})
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	var uptimes []mv1.LeaseUptime
+	var slaSettlements []mv1.LeaseSLASettlement
+	var slaRecords []mv1.LeaseSLARecord
+
+	err = k.LeaseUptimes().Walk(ctx, nil, func(_ keys.LeaseUptimeKey, value mv1.LeaseUptime) (bool, error) {
+		uptimes = append(uptimes, value)
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = k.LeaseSLASettlements().Walk(ctx, nil, func(key keys.LeaseSLASettlementKey) (bool, error) {
+		slaSettlements = append(slaSettlements, mv1.LeaseSLASettlement{
+			ID:     keys.KeyToLeaseID(key.K2().K1()),
+			Epoch:  key.K2().K2(),
+			Height: key.K1(),
+		})
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = k.LeaseSLARecords().Walk(ctx, nil, func(_ keys.LeaseSLARecordKey, value mv1.LeaseSLARecord) (bool, error) {
+		slaRecords = append(slaRecords, value)
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	return &mvbeta.GenesisState{
-		Params: params,
-		Orders: orders,
-		Leases: leases,
-		Bids:   bids,
+		Params:               params,
+		LeaseMigrations:      migrations,
+		LeaseMigrationCloses: migrationCloses,
+		LeaseUptimes:         uptimes,
+		LeaseSLASettlements:  slaSettlements,
+		LeaseSLARecords:      slaRecords,
 	}
 }
 
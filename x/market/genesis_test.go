@@ -0,0 +1,91 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	"pkg.akt.dev/go/sdkutil"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/testutil/state"
+	"pkg.akt.dev/node/v2/x/market"
+)
+
+func TestGenesisLeaseMigrations(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+
+	lid := testutil.LeaseID(t)
+
+	data := &mvbeta.GenesisState{
+		Params: mvbeta.DefaultParams(),
+		LeaseMigrations: []mv1.LeaseMigration{
+			{
+				ID:      lid,
+				OrderID: mv1.MakeOrderID(lid.GroupID(), lid.OSeq+1),
+				Overlap: time.Hour,
+			},
+		},
+		LeaseMigrationCloses: []mv1.LeaseMigrationClose{
+			{
+				ID:       testutil.LeaseID(t),
+				Deadline: 1000,
+			},
+		},
+	}
+
+	market.InitGenesis(suite.Context(), suite.MarketKeeper(), data)
+
+	exported := market.ExportGenesis(suite.Context(), suite.MarketKeeper())
+	require.Equal(t, data.LeaseMigrations, exported.LeaseMigrations)
+	require.Equal(t, data.LeaseMigrationCloses, exported.LeaseMigrationCloses)
+}
+
+func TestGenesisLeaseSLAs(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+
+	lid := testutil.LeaseID(t)
+
+	data := &mvbeta.GenesisState{
+		Params: mvbeta.DefaultParams(),
+		LeaseUptimes: []mv1.LeaseUptime{
+			{
+				ID:     lid,
+				Epoch:  3,
+				Signer: lid.Owner,
+				Uptime: 9500,
+				Height: 35,
+			},
+		},
+		LeaseSLASettlements: []mv1.LeaseSLASettlement{
+			{
+				ID:     lid,
+				Epoch:  3,
+				Height: 50,
+			},
+		},
+		LeaseSLARecords: []mv1.LeaseSLARecord{
+			{
+				ID:     lid,
+				Epoch:  2,
+				Uptime: 9800,
+				Target: 9900,
+				Refund: sdk.NewDecCoin(sdkutil.DenomUakt, sdkmath.NewInt(10)),
+				Height: 40,
+			},
+		},
+	}
+
+	market.InitGenesis(suite.Context(), suite.MarketKeeper(), data)
+
+	exported := market.ExportGenesis(suite.Context(), suite.MarketKeeper())
+	require.Equal(t, data.LeaseUptimes, exported.LeaseUptimes)
+	require.Equal(t, data.LeaseSLASettlements, exported.LeaseSLASettlements)
+	require.Equal(t, data.LeaseSLARecords, exported.LeaseSLARecords)
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dbeta "pkg.akt.dev/go/node/deployment/v1beta4"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+
+	"pkg.akt.dev/node/v2/util/metrics"
+)
+
+// maxExpiredPerBlock caps the number of items each processed by a single
+// EndBlocker sweep. Remaining items are picked up in next blocks.
+const maxExpiredPerBlock = 100
+
+// migratedLeaseCloseRetry is the delay a migrated lease failing to close is retried after
+const migratedLeaseCloseRetry = time.Hour
+
+// maxDrainRetries caps the number of passes over leases of a draining provider
+// which leave some of them failing to reclaim
+const maxDrainRetries = 10
+
+// EndBlocker closes leases replaced by a lease migration once their overlap
+// has elapsed, starts reclamation of leases of draining providers,
+// settles attested lease SLA epochs, and closes open bids older
+// than BidMaxAge and open orders older than OrderMaxAge. Zero value of either
+// age param disables the respective sweep.
+func EndBlocker(ctx sdk.Context, keepers Keepers) error {
+	params, err := keepers.Market.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := closeMigratedLeases(ctx, keepers); err != nil {
+		return err
+	}
+
+	if err := reclaimDrainingLeases(ctx, keepers); err != nil {
+		return err
+	}
+
+	if err := keepers.Market.SettleLeaseSLAs(ctx, maxExpiredPerBlock); err != nil {
+		return err
+	}
+
+	if params.BidMaxAge > 0 {
+		if err := expireBids(ctx, keepers, ctx.BlockHeight()-params.BidMaxAge); err != nil {
+			return err
+		}
+	}
+
+	if params.OrderMaxAge > 0 {
+		if err := expireOrders(ctx, keepers, ctx.BlockHeight()-params.OrderMaxAge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// closeMigratedLeases closes leases which overlap with their replacement lease has elapsed.
+// Group stays open as it is served by the replacement lease.
+func closeMigratedLeases(ctx sdk.Context, keepers Keepers) error {
+	dues, err := keepers.Market.DueMigratedLeases(ctx, maxExpiredPerBlock)
+	if err != nil {
+		return err
+	}
+
+	closed := 0
+
+	for _, due := range dues {
+		// failure to close single lease must not halt the chain, it is left open along with
+		// its schedule entry, deferred so it does not hold back leases due after it
+		cctx, write := ctx.CacheContext()
+		if err := closeMigratedLease(cctx, keepers, due); err != nil {
+			ctx.Logger().Error("failed to close migrated lease", "lease", due.ID, "error", err)
+
+			if err := keepers.Market.DeferMigratedLeaseClose(ctx, due, ctx.BlockTime().Add(migratedLeaseCloseRetry).Unix()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		write()
+		closed++
+	}
+
+	metrics.AddEndBlockerProcessed(mv1.ModuleName, "migrated_leases_closed", closed)
+
+	return nil
+}
+
+// closeMigratedLease removes replaced lease from the close schedule and closes it
+// along with its bid, order and payment, unless it has been closed in the meantime
+func closeMigratedLease(ctx sdk.Context, keepers Keepers, due mv1.LeaseMigrationClose) error {
+	if err := keepers.Market.RemoveMigratedLeaseClose(ctx, due); err != nil {
+		return err
+	}
+
+	id := due.ID
+
+	lease, found := keepers.Market.GetLease(ctx, id)
+	if !found || (lease.State != mv1.LeaseActive && lease.State != mv1.LeaseReclaiming) {
+		return nil
+	}
+
+	if err := keepers.Market.OnLeaseClosed(ctx, lease, mv1.LeaseClosed, mv1.LeaseClosedReasonMigrated); err != nil {
+		return err
+	}
+
+	if bid, found := keepers.Market.GetBid(ctx, id.BidID()); found {
+		if err := keepers.Market.OnBidClosed(ctx, bid); err != nil {
+			return err
+		}
+	}
+
+	if order, found := keepers.Market.GetOrder(ctx, id.OrderID()); found {
+		if err := keepers.Market.OnOrderClosed(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	if err := keepers.Escrow.PaymentClose(ctx, id.ToEscrowPaymentID()); err != nil {
+		ctx.Logger().With("err", err).Info("error closing payment of migrated lease")
+	}
+
+	return nil
+}
+
+// reclaimDrainingLeases starts reclamation of active reclaimable leases of providers
+// which requested it along with draining status. Only reclaimed leases count against
+// the per block budget. Leases failing to reclaim are skipped for the rest of the block;
+// provider stays queued while such leases remain and its pass is retried in next blocks,
+// up to maxDrainRetries times, after which it is removed from the queue leaving them active.
+func reclaimDrainingLeases(ctx sdk.Context, keepers Keepers) error {
+	var providers []sdk.AccAddress
+
+	keepers.Provider.WithDrainingProviders(ctx, func(id sdk.AccAddress) bool {
+		providers = append(providers, id)
+		return len(providers) >= maxExpiredPerBlock
+	})
+
+	budget := maxExpiredPerBlock
+	// failures bounds the number of failed reclaim attempts within a single block
+	failures := maxExpiredPerBlock
+
+	for _, provider := range providers {
+		if budget == 0 || failures == 0 {
+			break
+		}
+
+		// provider may have left draining state with reclaim requested in the meantime
+		if keepers.Provider.EffectiveState(ctx, provider) != ptypes.ProviderDraining {
+			if err := keepers.Provider.OnDrainCompleted(ctx, provider); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		reclaimed, failed, remaining := reclaimProviderLeases(ctx, keepers, provider, budget, failures)
+
+		budget -= reclaimed
+		failures -= failed
+
+		if reclaimed > 0 {
+			ctx.Logger().Info("started reclamation of draining provider leases", "provider", provider, "count", reclaimed)
+			telemetry.IncrCounter(float32(reclaimed), "akash.leases_drained")
+			metrics.AddEndBlockerProcessed(mv1.ModuleName, "leases_drained", reclaimed)
+		}
+
+		switch {
+		case failed > 0 && (!remaining || failures == 0):
+			// pass over provider leases ended with some of them left unreclaimed
+			retries, err := keepers.Provider.OnDrainFailed(ctx, provider)
+			if err != nil {
+				return err
+			}
+
+			if retries >= maxDrainRetries {
+				ctx.Logger().Error("giving up reclamation of draining provider leases", "provider", provider, "retries", retries)
+
+				if err := keepers.Provider.OnDrainCompleted(ctx, provider); err != nil {
+					return err
+				}
+			}
+		case remaining:
+			// budget is exhausted, reclamation continues in next block
+		default:
+			if err := keepers.Provider.OnDrainCompleted(ctx, provider); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reclaimProviderLeases starts reclamation of up to budget reclaimable leases of the provider,
+// stopping after maxFailures failed attempts. It returns number of reclaimed and failed leases,
+// and whether reclaimable leases which have not been attempted are left.
+func reclaimProviderLeases(ctx sdk.Context, keepers Keepers, provider sdk.AccAddress, budget, maxFailures int) (int, int, bool) {
+	ms := msgServer{keepers: keepers}
+
+	failed := make(map[string]struct{})
+	reclaimed := 0
+	remaining := false
+
+	for reclaimed < budget && len(failed) < maxFailures {
+		leases := make([]mv1.Lease, 0)
+		remaining = false
+
+		keepers.Market.WithLeasesForProvider(ctx, provider.String(), func(lease mv1.Lease) bool {
+			if lease.State != mv1.LeaseActive || lease.Reclamation == nil || lease.Reclamation.StartedAt != 0 {
+				return false
+			}
+
+			if _, skip := failed[lease.ID.String()]; skip {
+				return false
+			}
+
+			if len(leases) >= budget-reclaimed {
+				remaining = true
+				return true
+			}
+
+			leases = append(leases, lease)
+			return false
+		})
+
+		if len(leases) == 0 {
+			break
+		}
+
+		for _, lease := range leases {
+			if len(failed) >= maxFailures {
+				remaining = true
+				break
+			}
+
+			// failure to reclaim single lease must not halt the chain, it is left active
+			cctx, write := ctx.CacheContext()
+			if err := ms.startLeaseReclaim(cctx, lease, mv1.LeaseClosedReasonDraining); err != nil {
+				ctx.Logger().Error("failed to start reclamation of draining provider lease", "lease", lease.ID, "error", err)
+				failed[lease.ID.String()] = struct{}{}
+				continue
+			}
+
+			write()
+			reclaimed++
+		}
+	}
+
+	return reclaimed, len(failed), remaining
+}
+
+// expireBids closes open bids created at or before cutoff height.
+// Closing a bid closes its escrow account and returns the deposit to the provider.
+func expireBids(ctx sdk.Context, keepers Keepers, cutoff int64) error {
+	if cutoff < 0 {
+		return nil
+	}
+
+	bids, err := keepers.Market.NextBidsCreatedUntil(ctx, mvbeta.BidOpen, cutoff, maxExpiredPerBlock)
+	if err != nil {
+		return err
+	}
+
+	closed := 0
+
+	for _, bid := range bids {
+		// failure to close single bid must not halt the chain, the sweep moves past it
+		// and retries it once it starts over from the oldest bid
+		cctx, write := ctx.CacheContext()
+		if err := keepers.Market.OnBidClosed(cctx, bid); err != nil {
+			ctx.Logger().Error("failed to close expired bid", "bid", bid.ID, "error", err)
+			continue
+		}
+
+		write()
+		closed++
+	}
+
+	if closed > 0 {
+		ctx.Logger().Info("closed expired bids", "count", closed, "cutoff", cutoff)
+		telemetry.IncrCounter(float32(closed), "akash.bids_expired")
+		metrics.AddEndBlockerProcessed(mv1.ModuleName, "bids_expired", closed)
+	}
+
+	return nil
+}
+
+// expireOrders closes open orders created at or before cutoff height along with
+// their open bids. Group of the timed out order is paused, so tenant can restart it
+// with StartGroup once ready, unless it is still served by a lease being migrated.
+func expireOrders(ctx sdk.Context, keepers Keepers, cutoff int64) error {
+	if cutoff < 0 {
+		return nil
+	}
+
+	orders, err := keepers.Market.NextOrdersCreatedUntil(ctx, mvbeta.OrderOpen, cutoff, maxExpiredPerBlock)
+	if err != nil {
+		return err
+	}
+
+	closed := 0
+
+	for _, order := range orders {
+		// failure to close single order must not halt the chain, the sweep moves past it
+		// and retries it once it starts over from the oldest order
+		cctx, write := ctx.CacheContext()
+		if err := expireOrder(cctx, keepers, order); err != nil {
+			ctx.Logger().Error("failed to close timed out order", "order", order.ID, "error", err)
+			continue
+		}
+
+		write()
+		closed++
+	}
+
+	if closed > 0 {
+		ctx.Logger().Info("closed timed out orders", "count", closed, "cutoff", cutoff)
+		telemetry.IncrCounter(float32(closed), "akash.orders_timed_out")
+		metrics.AddEndBlockerProcessed(mv1.ModuleName, "orders_timed_out", closed)
+	}
+
+	return nil
+}
+
+// expireOrder closes timed out order with its open bids and pauses its group.
+// Timed out replacement order of a lease migration drops the pending migration
+// and leaves the group open, as the lease being migrated keeps running.
+func expireOrder(ctx sdk.Context, keepers Keepers, order mvbeta.Order) error {
+	if err := keepers.Market.OnOrderTimedOut(ctx, order); err != nil {
+		return err
+	}
+
+	bids := make([]mvbeta.Bid, 0)
+	keepers.Market.WithBidsForOrder(ctx, order.ID, mvbeta.BidOpen, func(bid mvbeta.Bid) bool {
+		bids = append(bids, bid)
+		return false
+	})
+
+	for _, bid := range bids {
+		if err := keepers.Market.OnBidClosed(ctx, bid); err != nil {
+			return err
+		}
+	}
+
+	group, found := keepers.Deployment.GetGroup(ctx, order.ID.GroupID())
+	if !found || group.State != dbeta.GroupOpen {
+		return nil
+	}
+
+	ms := msgServer{keepers: keepers}
+	if ms.groupHasOtherOrders(ctx, group.ID, order.ID) {
+		return nil
+	}
+
+	return keepers.Deployment.OnBidClosed(ctx, group.ID)
+}
@@ -0,0 +1,281 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "pkg.akt.dev/go/node/deployment/v1beta4"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/x/market/handler"
+)
+
+func TestEndBlockerExpiresBids(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	params, err := suite.MarketKeeper().GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.BidMaxAge = 10
+	params.OrderMaxAge = 0
+	require.NoError(t, suite.MarketKeeper().SetParams(suite.Context(), params))
+
+	suite.SetBlockHeight(1)
+	bid, order := suite.createBid()
+
+	// bid is not old enough yet
+	suite.SetBlockHeight(10)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	res, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.BidOpen, res.State)
+
+	suite.SetBlockHeight(11)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	res, found = suite.MarketKeeper().GetBid(suite.Context(), bid.ID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.BidClosed, res.State)
+
+	// order age is disabled, order must stay open
+	resOrder, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.OrderOpen, resOrder.State)
+}
+
+func TestEndBlockerTimesOutOrders(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	params, err := suite.MarketKeeper().GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.BidMaxAge = 0
+	params.OrderMaxAge = 5
+	require.NoError(t, suite.MarketKeeper().SetParams(suite.Context(), params))
+
+	suite.SetBlockHeight(1)
+	bid, order := suite.createBid()
+
+	suite.SetBlockHeight(6)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	resOrder, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.OrderClosed, resOrder.State)
+
+	resBid, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.BidClosed, resBid.State)
+
+	group, found := suite.DeploymentKeeper().GetGroup(suite.Context(), order.ID.GroupID())
+	require.True(t, found)
+	require.Equal(t, dtypes.GroupPaused, group.State)
+}
+
+func TestEndBlockerTimesOutMigrationOrder(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	params, err := suite.MarketKeeper().GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.BidMaxAge = 0
+	params.OrderMaxAge = 5
+	params.MaxLeaseMigrationOverlap = time.Hour
+	require.NoError(t, suite.MarketKeeper().SetParams(suite.Context(), params))
+
+	suite.SetBlockHeight(1)
+	lid, _, order := suite.createLease()
+
+	suite.SetBlockHeight(2)
+	_, err = suite.handler(suite.Context(), &mvbeta.MsgMigrateLease{
+		ID:      lid,
+		Overlap: time.Hour,
+	})
+	require.NoError(t, err)
+
+	norderID := mv1.MakeOrderID(lid.GroupID(), order.ID.OSeq+1)
+
+	norder, found := suite.MarketKeeper().GetOrder(suite.Context(), norderID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.OrderOpen, norder.State)
+
+	suite.SetBlockHeight(7)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	norder, found = suite.MarketKeeper().GetOrder(suite.Context(), norderID)
+	require.True(t, found)
+	require.Equal(t, mvbeta.OrderClosed, norder.State)
+
+	// group keeps being served by the lease which was to be migrated
+	group, found := suite.DeploymentKeeper().GetGroup(suite.Context(), lid.GroupID())
+	require.True(t, found)
+	require.Equal(t, dtypes.GroupOpen, group.State)
+
+	lease, found := suite.MarketKeeper().GetLease(suite.Context(), lid)
+	require.True(t, found)
+	require.Equal(t, mv1.LeaseActive, lease.State)
+
+	// pending migration is dropped, so lease is never scheduled to be closed
+	ctx := suite.Context().WithBlockTime(time.Now())
+	require.NoError(t, suite.MarketKeeper().OnMigrationLeaseCreated(ctx, mv1.MakeBidID(norderID, testutil.AccAddress(t)).LeaseID()))
+
+	due, err := suite.MarketKeeper().DueMigratedLeases(ctx.WithBlockTime(time.Now().Add(24*time.Hour)), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func TestEndBlockerClosesMigratedLease(t *testing.T) {
+	suite := setupTestSuite(t)
+	prepareBlanketMocks(suite)
+
+	params, err := suite.MarketKeeper().GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.BidMaxAge = 0
+	params.OrderMaxAge = 0
+	require.NoError(t, suite.MarketKeeper().SetParams(suite.Context(), params))
+
+	suite.SetBlockHeight(1)
+	lid, _, order := suite.createLease()
+
+	lease, found := suite.MarketKeeper().GetLease(suite.Context(), lid)
+	require.True(t, found)
+
+	norder, err := suite.MarketKeeper().CreateMigrationOrder(suite.Context(), lease, order.Spec, nil, time.Hour)
+	require.NoError(t, err)
+
+	ctx := suite.Context().WithBlockTime(time.Unix(1000, 0))
+	require.NoError(t, suite.MarketKeeper().OnMigrationLeaseCreated(ctx, mv1.MakeBidID(norder.ID, testutil.AccAddress(t)).LeaseID()))
+
+	// overlap has not elapsed yet
+	require.NoError(t, handler.EndBlocker(ctx.WithBlockTime(time.Unix(1000, 0).Add(time.Minute)), suite.keepers))
+
+	lease, found = suite.MarketKeeper().GetLease(suite.Context(), lid)
+	require.True(t, found)
+	require.Equal(t, mv1.LeaseActive, lease.State)
+
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0).Add(time.Hour))
+	require.NoError(t, handler.EndBlocker(ctx, suite.keepers))
+
+	lease, found = suite.MarketKeeper().GetLease(suite.Context(), lid)
+	require.True(t, found)
+	require.Equal(t, mv1.LeaseClosed, lease.State)
+	require.Equal(t, mv1.LeaseClosedReasonMigrated, lease.Reason)
+
+	due, err := suite.MarketKeeper().DueMigratedLeases(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func TestEndBlockerReclaimsDrainingProviderLeases(t *testing.T) {
+	suite := setupTestSuite(t)
+	prepareBlanketMocks(suite)
+
+	params, err := suite.MarketKeeper().GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.BidMaxAge = 0
+	params.OrderMaxAge = 0
+	require.NoError(t, suite.MarketKeeper().SetParams(suite.Context(), params))
+
+	provider := testutil.AccAddress(t)
+	window := 24 * time.Hour
+
+	suite.SetBlockHeight(1)
+
+	// one lease more than reclaimed per block, plus one without reclamation which is never reclaimed
+	leases := make([]mv1.LeaseID, 0, 101)
+	for i := 0; i < 101; i++ {
+		leases = append(leases, suite.createProviderLease(provider, &window))
+	}
+
+	fixed := suite.createProviderLease(provider, nil)
+
+	err = suite.ProviderKeeper().SetStatus(suite.Context(), provider, ptypes.ProviderStatus{
+		State:  ptypes.ProviderDraining,
+		Reason: "decommissioning",
+	}, true)
+	require.NoError(t, err)
+
+	draining := func() []sdk.AccAddress {
+		var res []sdk.AccAddress
+		suite.ProviderKeeper().WithDrainingProviders(suite.Context(), func(id sdk.AccAddress) bool {
+			res = append(res, id)
+			return false
+		})
+		return res
+	}
+
+	countState := func(state mv1.Lease_State) int {
+		count := 0
+		for _, lid := range leases {
+			lease, found := suite.MarketKeeper().GetLease(suite.Context(), lid)
+			require.True(t, found)
+
+			if lease.State == state {
+				count++
+			}
+		}
+		return count
+	}
+
+	suite.SetBlockHeight(2)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	// reclamation of at most 100 leases is started per block, provider stays queued for the rest
+	require.Equal(t, 100, countState(mv1.LeaseReclaiming))
+	require.Equal(t, 1, countState(mv1.LeaseActive))
+	require.Equal(t, []sdk.AccAddress{provider}, draining())
+
+	suite.SetBlockHeight(3)
+	require.NoError(t, handler.EndBlocker(suite.Context(), suite.keepers))
+
+	require.Equal(t, 101, countState(mv1.LeaseReclaiming))
+	require.Empty(t, draining())
+
+	lease, found := suite.MarketKeeper().GetLease(suite.Context(), fixed)
+	require.True(t, found)
+	require.Equal(t, mv1.LeaseActive, lease.State)
+}
+
+// createProviderLease creates active lease of the provider, reclaimable within window if set
+func (st *testSuite) createProviderLease(provider sdk.AccAddress, window *time.Duration) mv1.LeaseID {
+	st.t.Helper()
+
+	order, gspec := st.createOrder(testutil.Resources(st.t, testutil.WithDenom("uact")))
+
+	bid, err := st.MarketKeeper().CreateBid(
+		st.Context(),
+		mv1.MakeBidID(order.ID, provider),
+		order.Price(),
+		mvbeta.ResourceOfferFromRU(gspec.Resources),
+		window,
+		nil,
+	)
+	require.NoError(st.t, err)
+
+	require.NoError(st.t, st.MarketKeeper().CreateLease(st.Context(), bid))
+
+	if window != nil {
+		lease, found := st.MarketKeeper().GetLease(st.Context(), bid.ID.LeaseID())
+		require.True(st.t, found)
+
+		lease.Reclamation = &mv1.Reclamation{
+			Window: *window,
+		}
+		require.NoError(st.t, st.MarketKeeper().SaveLease(st.Context(), lease))
+	}
+
+	st.MarketKeeper().OnBidMatched(st.Context(), bid)
+	st.MarketKeeper().OnOrderMatched(st.Context(), order)
+
+	return bid.ID.LeaseID()
+}
@@ -17,6 +17,9 @@ func NewHandler(keepers Keepers) baseapp.MsgServiceHandler {
 		case *mvbeta.MsgCreateBid:
 			res, err := ms.CreateBid(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *mvbeta.MsgUpdateBid:
+			res, err := ms.UpdateBid(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		case *mvbeta.MsgCloseBid:
 			res, err := ms.CloseBid(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
@@ -29,6 +32,12 @@ func NewHandler(keepers Keepers) baseapp.MsgServiceHandler {
 		case *mvbeta.MsgCloseLease:
 			res, err := ms.CloseLease(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *mvbeta.MsgMigrateLease:
+			res, err := ms.MigrateLease(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *mvbeta.MsgAttestLeaseUptime:
+			res, err := ms.AttestLeaseUptime(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		case *mvbeta.MsgLeaseStartReclaim:
 			res, err := ms.LeaseStartReclaim(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
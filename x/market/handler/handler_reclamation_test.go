@@ -132,7 +132,7 @@ func (st *testSuite) createBidWithReclamation(reclaimWindow *time.Duration) (mvb
 
 	bidID := mv1.MakeBidID(order.ID, provider)
 
-	bid, err := st.MarketKeeper().CreateBid(st.Context(), bidID, price, roffer, reclaimWindow)
+	bid, err := st.MarketKeeper().CreateBid(st.Context(), bidID, price, roffer, reclaimWindow, nil)
 	require.NoError(st.t, err)
 
 	return bid, order
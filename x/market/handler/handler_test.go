@@ -40,6 +40,7 @@ import (
 
 type testSuite struct {
 	*state.TestSuite
+	keepers  handler.Keepers
 	handler  baseapp.MsgServiceHandler
 	dhandler baseapp.MsgServiceHandler
 	ehandler baseapp.MsgServiceHandler
@@ -48,17 +49,20 @@ type testSuite struct {
 
 func setupTestSuite(t *testing.T) *testSuite {
 	ssuite := state.SetupTestSuite(t)
+	keepers := handler.Keepers{
+		Escrow:     ssuite.EscrowKeeper(),
+		Audit:      ssuite.AuditKeeper(),
+		Market:     ssuite.MarketKeeper(),
+		Deployment: ssuite.DeploymentKeeper(),
+		Provider:   ssuite.ProviderKeeper(),
+		Bank:       ssuite.BankKeeper(),
+	}
+
 	suite := &testSuite{
 		t:         t,
 		TestSuite: ssuite,
-		handler: handler.NewHandler(handler.Keepers{
-			Escrow:     ssuite.EscrowKeeper(),
-			Audit:      ssuite.AuditKeeper(),
-			Market:     ssuite.MarketKeeper(),
-			Deployment: ssuite.DeploymentKeeper(),
-			Provider:   ssuite.ProviderKeeper(),
-			Bank:       ssuite.BankKeeper(),
-		}),
+		keepers:   keepers,
+		handler:   handler.NewHandler(keepers),
 	}
 
 	suite.dhandler = dhandler.NewHandler(suite.DeploymentKeeper(), suite.MarketKeeper(), ssuite.EscrowKeeper(), ssuite.BmeKeeper(), ssuite.BankKeeper())
@@ -1095,6 +1099,46 @@ func TestCreateBidInvalidAttributes(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCreateBidDrainingProvider(t *testing.T) {
+	suite := setupTestSuite(t)
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		bkeeper := ts.BankKeeper()
+
+		bkeeper.
+			On("SendCoinsFromAccountToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("SendCoinsFromModuleToAccount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("SendCoinsFromModuleToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+	})
+
+	order, gspec := suite.createOrder(testutil.Resources(t, testutil.WithDenom("uact")))
+	providerAddr, err := sdk.AccAddressFromBech32(suite.createProvider(gspec.Requirements.Attributes).Owner)
+	require.NoError(t, err)
+
+	err = suite.ProviderKeeper().SetStatus(suite.Context(), providerAddr, ptypes.ProviderStatus{
+		State:  ptypes.ProviderDraining,
+		Reason: "decommissioning",
+	}, false)
+	require.NoError(t, err)
+
+	msg := &mvbeta.MsgCreateBid{
+		ID:    mv1.MakeBidID(order.ID, providerAddr),
+		Price: sdk.NewDecCoin(sdkutil.DenomUact, sdkmath.NewInt(1)),
+		Deposit: deposit.Deposit{
+			Amount:  mvbeta.DefaultBidMinDepositACT,
+			Sources: deposit.Sources{deposit.SourceBalance},
+		},
+	}
+
+	res, err := suite.handler(suite.Context(), msg)
+	require.Nil(t, res)
+	require.ErrorIs(t, err, mv1.ErrProviderNotAccepting)
+}
+
 func TestCreateBidAlreadyExists(t *testing.T) {
 	suite := setupTestSuite(t)
 
@@ -1142,6 +1186,89 @@ func TestCreateBidAlreadyExists(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestUpdateBid(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		bkeeper := ts.BankKeeper()
+
+		// BME deposit flow mocks
+		bkeeper.
+			On("SendCoinsFromAccountToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("MintCoins", mock.Anything, bmemodule.ModuleName, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("BurnCoins", mock.Anything, bmemodule.ModuleName, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("SendCoinsFromModuleToAccount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		bkeeper.
+			On("SendCoinsFromModuleToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+	})
+
+	order, gspec := suite.createOrder(testutil.Resources(t, testutil.WithDenom("uact")))
+	provider := suite.createProvider(gspec.Requirements.Attributes).Owner
+	providerAddr, err := sdk.AccAddressFromBech32(provider)
+	require.NoError(t, err)
+
+	bidID := mv1.MakeBidID(order.ID, providerAddr)
+
+	res, err := suite.handler(suite.Context(), &mvbeta.MsgCreateBid{
+		ID:    bidID,
+		Price: sdk.NewDecCoin(sdkutil.DenomUact, sdkmath.NewInt(2)),
+		Deposit: deposit.Deposit{
+			Amount:  mvbeta.DefaultBidMinDepositACT,
+			Sources: deposit.Sources{deposit.SourceBalance},
+		},
+	})
+	require.NotNil(t, res)
+	require.NoError(t, err)
+
+	t.Run("price increase rejected", func(t *testing.T) {
+		res, err := suite.handler(suite.Context(), &mvbeta.MsgUpdateBid{
+			ID:    bidID,
+			Price: sdk.NewDecCoin(sdkutil.DenomUact, sdkmath.NewInt(3)),
+		})
+		require.Nil(t, res)
+		require.ErrorIs(t, err, mv1.ErrBidInvalidPrice)
+	})
+
+	t.Run("price decrease accepted", func(t *testing.T) {
+		msg := &mvbeta.MsgUpdateBid{
+			ID:    bidID,
+			Price: sdk.NewDecCoin(sdkutil.DenomUact, sdkmath.NewInt(1)),
+		}
+
+		res, err := suite.handler(suite.Context(), msg)
+		require.NotNil(t, res)
+		require.NoError(t, err)
+
+		testutil.EnsureEvent(t, res.Events, &mv1.EventBidUpdated{ID: bidID, Price: msg.Price})
+
+		bid, found := suite.MarketKeeper().GetBid(suite.Context(), bidID)
+		require.True(t, found)
+		require.Equal(t, msg.Price, bid.Price)
+		require.Equal(t, mvbeta.BidOpen, bid.State)
+	})
+
+	t.Run("closed bid rejected", func(t *testing.T) {
+		bid, found := suite.MarketKeeper().GetBid(suite.Context(), bidID)
+		require.True(t, found)
+		require.NoError(t, suite.MarketKeeper().OnBidClosed(suite.Context(), bid))
+
+		res, err := suite.handler(suite.Context(), &mvbeta.MsgUpdateBid{
+			ID:    bidID,
+			Price: sdk.NewDecCoin(sdkutil.DenomUact, sdkmath.NewInt(1)),
+		})
+		require.Nil(t, res)
+		require.ErrorIs(t, err, mv1.ErrBidNotOpen)
+	})
+}
+
 func TestCloseOrderNonExisting(t *testing.T) {
 	t.Skip("TODO CLOSE LEASE")
 	// suite := setupTestSuite(t)
@@ -1354,7 +1481,7 @@ func TestCloseBidUnknownOrder(t *testing.T) {
 	roffer := mvbeta.ResourceOfferFromRU(group.GroupSpec.Resources)
 
 	bidID := mv1.MakeBidID(orderID, provider)
-	bid, err := suite.MarketKeeper().CreateBid(suite.Context(), bidID, price, roffer, nil)
+	bid, err := suite.MarketKeeper().CreateBid(suite.Context(), bidID, price, roffer, nil, nil)
 	require.NoError(t, err)
 
 	err = suite.MarketKeeper().CreateLease(suite.Context(), bid)
@@ -1393,7 +1520,7 @@ func (st *testSuite) createBid() (mvbeta.Bid, mvbeta.Order) {
 
 	bidID := mv1.MakeBidID(order.ID, provider)
 
-	bid, err := st.MarketKeeper().CreateBid(st.Context(), bidID, price, roffer, nil)
+	bid, err := st.MarketKeeper().CreateBid(st.Context(), bidID, price, roffer, nil, nil)
 	require.NoError(st.t, err)
 	require.Equal(st.t, order.ID, bid.ID.OrderID())
 	require.Equal(st.t, price, bid.Price)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	authzkeeper "github.com/cosmos/cosmos-sdk/x/authz/keeper"
@@ -26,6 +27,7 @@ type EscrowKeeper interface {
 	PaymentCreate(ctx sdk.Context, id escrowid.Payment, provider sdk.AccAddress, rate sdk.DecCoin) error
 	PaymentWithdraw(ctx sdk.Context, id escrowid.Payment) error
 	PaymentClose(ctx sdk.Context, id escrowid.Payment) error
+	PaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) error
 	AuthorizeDeposits(sctx sdk.Context, msg sdk.Msg) ([]etypes.Depositor, error)
 }
 
@@ -33,10 +35,19 @@ type EscrowKeeper interface {
 type ProviderKeeper interface {
 	Get(ctx sdk.Context, id sdk.Address) (ptypes.Provider, bool)
 	WithProviders(ctx sdk.Context, fn func(ptypes.Provider) bool)
+	GetParams(ctx sdk.Context) (ptypes.Params, error)
+	ValidateBidBond(ctx sdk.Context, id sdk.AccAddress, price sdk.DecCoin) error
+	Slash(ctx sdk.Context, id sdk.AccAddress, fraction sdkmath.LegacyDec, recipient sdk.AccAddress) (sdk.Coins, error)
+	EffectiveState(ctx sdk.Context, id sdk.AccAddress) ptypes.ProviderState
+	WithDrainingProviders(ctx sdk.Context, fn func(sdk.AccAddress) bool)
+	OnDrainCompleted(ctx sdk.Context, id sdk.AccAddress) error
+	OnDrainFailed(ctx sdk.Context, id sdk.AccAddress) (uint32, error)
 }
 
 type AuditKeeper interface {
 	GetProviderAttributes(ctx sdk.Context, id sdk.Address) (atypes.AuditedProviders, bool)
+	GetProviderByAuditor(ctx sdk.Context, id atypes.ProviderID) (atypes.AuditedProvider, bool)
+	GetAuditor(ctx sdk.Context, addr sdk.AccAddress) (atypes.Auditor, bool)
 }
 
 // DeploymentKeeper Interface includes deployment methods
@@ -3,15 +3,22 @@ package handler
 import (
 	"context"
 	"fmt"
+	"time"
 
+	sdkmath "cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	atypes "pkg.akt.dev/go/node/audit/v1"
+	dv1 "pkg.akt.dev/go/node/deployment/v1"
 	dbeta "pkg.akt.dev/go/node/deployment/v1beta4"
+	etypes "pkg.akt.dev/go/node/escrow/types/v1"
 	mv1 "pkg.akt.dev/go/node/market/v1"
 	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
 	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+	deposit "pkg.akt.dev/go/node/types/deposit/v1"
+
+	akeeper "pkg.akt.dev/node/v2/x/audit/keeper"
 )
 
 type msgServer struct {
@@ -55,84 +62,77 @@ func (ms msgServer) CreateBid(goCtx context.Context, msg *mvbeta.MsgCreateBid) (
 		return nil, mv1.ErrOrderNotFound
 	}
 
-	if err := order.ValidateCanBid(); err != nil {
+	provider, err := ms.validateBidOffer(ctx, params, order, msg.ID.Provider, msg.Price, msg.ResourcesOffer, msg.ReclamationWindow)
+	if err != nil {
 		return nil, err
 	}
 
-	if !msg.Price.IsValid() {
-		return nil, mv1.ErrBidInvalidPrice
+	if err := ms.keepers.Provider.ValidateBidBond(ctx, provider, msg.Price); err != nil {
+		return nil, err
 	}
 
-	if order.Price().IsLT(msg.Price) {
-		return nil, mv1.ErrBidInvalidPrice
+	deposits, err := ms.keepers.Escrow.AuthorizeDeposits(ctx, msg)
+	if err != nil {
+		return nil, err
 	}
 
-	if !msg.ResourcesOffer.MatchGSpec(order.Spec) {
-		return nil, mv1.ErrCapabilitiesMismatch
+	bid, err := ms.keepers.Market.CreateBid(ctx, msg.ID, msg.Price, msg.ResourcesOffer, msg.ReclamationWindow, msg.SLA)
+	if err != nil {
+		return nil, err
 	}
 
-	provider, err := sdk.AccAddressFromBech32(msg.ID.Provider)
+	// create an escrow account for this bid
+	err = ms.keepers.Escrow.AccountCreate(ctx, bid.ID.ToEscrowAccountID(), provider, deposits)
 	if err != nil {
-		return nil, mv1.ErrEmptyProvider
+		return &mvbeta.MsgCreateBidResponse{}, err
 	}
 
-	var prov ptypes.Provider
-	if prov, found = ms.keepers.Provider.Get(ctx, provider); !found {
-		return nil, mv1.ErrUnknownProvider
-	}
+	telemetry.IncrCounter(1.0, "akash.bids")
+	return &mvbeta.MsgCreateBidResponse{}, nil
+}
 
-	provAttr, _ := ms.keepers.Audit.GetProviderAttributes(ctx, provider)
+func (ms msgServer) UpdateBid(goCtx context.Context, msg *mvbeta.MsgUpdateBid) (*mvbeta.MsgUpdateBidResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	provAttr = append([]atypes.AuditedProvider{{
-		Owner:      msg.ID.Provider,
-		Attributes: prov.Attributes,
-	}}, provAttr...)
+	params, err := ms.keepers.Market.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if !order.MatchRequirements(provAttr) {
-		return nil, mv1.ErrAttributeMismatch
+	bid, found := ms.keepers.Market.GetBid(ctx, msg.ID)
+	if !found {
+		return nil, mv1.ErrUnknownBid
 	}
 
-	if !order.MatchResourcesRequirements(prov.Attributes) {
-		return nil, mv1.ErrCapabilitiesMismatch
+	if bid.State != mvbeta.BidOpen {
+		return nil, mv1.ErrBidNotOpen
 	}
 
-	deposits, err := ms.keepers.Escrow.AuthorizeDeposits(ctx, msg)
-	if err != nil {
-		return nil, err
+	order, found := ms.keepers.Market.GetOrder(ctx, msg.ID.OrderID())
+	if !found {
+		return nil, mv1.ErrUnknownOrderForBid
 	}
 
-	// Reclamation validation
-	if order.RequiresReclamation() {
-		if msg.ReclamationWindow == nil {
-			return nil, mv1.ErrReclamationRequired
-		}
-		if *msg.ReclamationWindow < order.Reclamation.MinWindow {
-			return nil, mv1.ErrReclamationWindowTooShort
-		}
+	if _, err = ms.validateBidOffer(ctx, params, order, msg.ID.Provider, msg.Price, msg.ResourcesOffer, msg.ReclamationWindow); err != nil {
+		return nil, err
 	}
 
-	if msg.ReclamationWindow != nil {
-		if *msg.ReclamationWindow < params.MinReclamationWindow {
-			return nil, mv1.ErrReclamationWindowInvalid
-		}
-		if *msg.ReclamationWindow > params.MaxReclamationWindow {
-			return nil, mv1.ErrReclamationWindowInvalid
-		}
+	// bid can be replaced with same or lower price only
+	if msg.Price.Denom != bid.Price.Denom || bid.Price.IsLT(msg.Price) {
+		return nil, fmt.Errorf("%w: price may only be lowered", mv1.ErrBidInvalidPrice)
 	}
 
-	bid, err := ms.keepers.Market.CreateBid(ctx, msg.ID, msg.Price, msg.ResourcesOffer, msg.ReclamationWindow)
-	if err != nil {
+	bid.Price = msg.Price
+	bid.ResourcesOffer = msg.ResourcesOffer
+	bid.ReclamationWindow = msg.ReclamationWindow
+
+	if err = ms.keepers.Market.UpdateBid(ctx, bid); err != nil {
 		return nil, err
 	}
 
-	// create an escrow account for this bid
-	err = ms.keepers.Escrow.AccountCreate(ctx, bid.ID.ToEscrowAccountID(), provider, deposits)
-	if err != nil {
-		return &mvbeta.MsgCreateBidResponse{}, err
-	}
+	telemetry.IncrCounter(1.0, "akash.bids_updated")
 
-	telemetry.IncrCounter(1.0, "akash.bids")
-	return &mvbeta.MsgCreateBidResponse{}, nil
+	return &mvbeta.MsgUpdateBidResponse{}, nil
 }
 
 func (ms msgServer) CloseBid(goCtx context.Context, msg *mvbeta.MsgCloseBid) (*mvbeta.MsgCloseBidResponse, error) {
@@ -176,8 +176,21 @@ func (ms msgServer) CloseBid(goCtx context.Context, msg *mvbeta.MsgCloseBid) (*m
 		return nil, mv1.ErrBidNotActive
 	}
 
-	if err := ms.keepers.Deployment.OnBidClosed(ctx, order.ID.GroupID()); err != nil {
-		return nil, err
+	// closing a lease outside of reclamation is an abandonment by the provider
+	if lease.State == mv1.LeaseActive {
+		err := ms.slashEarlyTermination(ctx, lease, func(params ptypes.Params) sdkmath.LegacyDec {
+			return params.EarlyCloseSlashFraction
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// keep group running if it is being migrated to another provider
+	if !ms.groupHasOtherOrders(ctx, order.ID.GroupID(), order.ID) {
+		if err := ms.keepers.Deployment.OnBidClosed(ctx, order.ID.GroupID()); err != nil {
+			return nil, err
+		}
 	}
 
 	_ = ms.keepers.Market.OnLeaseClosed(ctx, lease, mv1.LeaseClosed, msg.Reason)
@@ -269,6 +282,10 @@ func (ms msgServer) CreateLease(goCtx context.Context, msg *mvbeta.MsgCreateLeas
 	ms.keepers.Market.OnOrderMatched(ctx, order)
 	ms.keepers.Market.OnBidMatched(ctx, bid)
 
+	if err = ms.keepers.Market.OnMigrationLeaseCreated(ctx, bid.ID.LeaseID()); err != nil {
+		return &mvbeta.MsgCreateLeaseResponse{}, err
+	}
+
 	// close losing bids
 	ms.keepers.Market.WithBidsForOrder(ctx, msg.BidID.OrderID(), mvbeta.BidOpen, func(cbid mvbeta.Bid) bool {
 		ms.keepers.Market.OnBidLost(ctx, cbid)
@@ -328,6 +345,11 @@ func (ms msgServer) CloseLease(goCtx context.Context, msg *mvbeta.MsgCloseLease)
 		return &mvbeta.MsgCloseLeaseResponse{}, nil
 	}
 
+	// group is still served by, or is being matched for, a migration replacement
+	if ms.groupHasOtherOrders(ctx, group.ID, order.ID) {
+		return &mvbeta.MsgCloseLeaseResponse{}, nil
+	}
+
 	if _, err := ms.keepers.Market.CreateOrder(ctx, group.ID, group.GroupSpec, order.Reclamation); err != nil {
 		return &mvbeta.MsgCloseLeaseResponse{}, err
 	}
@@ -335,6 +357,121 @@ func (ms msgServer) CloseLease(goCtx context.Context, msg *mvbeta.MsgCloseLease)
 	return &mvbeta.MsgCloseLeaseResponse{}, nil
 }
 
+func (ms msgServer) MigrateLease(goCtx context.Context, msg *mvbeta.MsgMigrateLease) (*mvbeta.MsgMigrateLeaseResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	params, err := ms.keepers.Market.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Overlap <= 0 || msg.Overlap > params.MaxLeaseMigrationOverlap {
+		return nil, fmt.Errorf("%w: overlap must be within (0, %s]", mv1.ErrInvalidLeaseMigration, params.MaxLeaseMigrationOverlap)
+	}
+
+	lease, found := ms.keepers.Market.GetLease(ctx, msg.ID)
+	if !found {
+		return nil, mv1.ErrUnknownLease
+	}
+
+	if lease.State != mv1.LeaseActive {
+		return nil, mv1.ErrLeaseNotActive
+	}
+
+	order, found := ms.keepers.Market.GetOrder(ctx, msg.ID.OrderID())
+	if !found {
+		return nil, mv1.ErrOrderNotFound
+	}
+
+	group, found := ms.keepers.Deployment.GetGroup(ctx, order.ID.GroupID())
+	if !found {
+		return nil, mv1.ErrGroupNotFound
+	}
+
+	if group.State != dbeta.GroupOpen {
+		return nil, mv1.ErrGroupNotOpen
+	}
+
+	if _, err = ms.keepers.Market.CreateMigrationOrder(ctx, lease, group.GroupSpec, order.Reclamation, msg.Overlap); err != nil {
+		return nil, err
+	}
+
+	telemetry.IncrCounter(1.0, "akash.lease_migrations")
+
+	return &mvbeta.MsgMigrateLeaseResponse{}, nil
+}
+
+func (ms msgServer) AttestLeaseUptime(goCtx context.Context, msg *mvbeta.MsgAttestLeaseUptime) (*mvbeta.MsgAttestLeaseUptimeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	params, err := ms.keepers.Market.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.SLAEpochBlocks <= 0 {
+		return nil, fmt.Errorf("%w: lease sla epochs are disabled", mv1.ErrInvalidUptimeAttestation)
+	}
+
+	lease, found := ms.keepers.Market.GetLease(ctx, msg.ID)
+	if !found {
+		return nil, mv1.ErrUnknownLease
+	}
+
+	if lease.SLA == nil {
+		return nil, fmt.Errorf("%w: lease has no sla", mv1.ErrInvalidUptimeAttestation)
+	}
+
+	// only complete epochs within lease lifetime may be attested,
+	// until the epoch is settled at the end of the following one
+	start := int64(msg.Epoch) * params.SLAEpochBlocks
+	end := start + params.SLAEpochBlocks
+
+	if ctx.BlockHeight() < end || ctx.BlockHeight() >= end+params.SLAEpochBlocks {
+		return nil, fmt.Errorf("%w: epoch %d is not open for attestation", mv1.ErrInvalidUptimeAttestation, msg.Epoch)
+	}
+
+	if end <= lease.CreatedAt || (lease.ClosedOn > 0 && start >= lease.ClosedOn) {
+		return nil, fmt.Errorf("%w: lease was not running in epoch %d", mv1.ErrInvalidUptimeAttestation, msg.Epoch)
+	}
+
+	auditor := false
+
+	if msg.Signer != lease.ID.Owner {
+		signer, err := sdk.AccAddressFromBech32(msg.Signer)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err := sdk.AccAddressFromBech32(lease.ID.Provider)
+		if err != nil {
+			return nil, err
+		}
+
+		// auditors which signed the provider's attributes are designated to attest its leases
+		if _, found := ms.keepers.Audit.GetProviderByAuditor(ctx, atypes.ProviderID{Owner: provider, Auditor: signer}); !found {
+			return nil, fmt.Errorf("%w: signer is neither tenant nor auditor of the provider", mv1.ErrInvalidUptimeAttestation)
+		}
+
+		auditor = true
+	}
+
+	err = ms.keepers.Market.AttestLeaseUptime(ctx, mv1.LeaseUptime{
+		ID:      lease.ID,
+		Epoch:   msg.Epoch,
+		Signer:  msg.Signer,
+		Auditor: auditor,
+		Uptime:  msg.Uptime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	telemetry.IncrCounter(1.0, "akash.lease_uptime_attestations")
+
+	return &mvbeta.MsgAttestLeaseUptimeResponse{}, nil
+}
+
 func (ms msgServer) LeaseStartReclaim(goCtx context.Context, msg *mvbeta.MsgLeaseStartReclaim) (*mvbeta.MsgLeaseStartReclaimResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -355,27 +492,240 @@ func (ms msgServer) LeaseStartReclaim(goCtx context.Context, msg *mvbeta.MsgLeas
 		return nil, mv1.ErrLeaseAlreadyReclaiming
 	}
 
+	if err := ms.startLeaseReclaim(ctx, lease, msg.Reason); err != nil {
+		return nil, err
+	}
+
+	return &mvbeta.MsgLeaseStartReclaimResponse{}, nil
+}
+
+// startLeaseReclaim moves active reclaimable lease into reclaiming state with deadline
+// set from its reclamation window. Provider's bond is slashed if lease is younger than min_lease_term.
+func (ms msgServer) startLeaseReclaim(ctx sdk.Context, lease mv1.Lease, reason mv1.LeaseClosedReason) error {
+	err := ms.slashEarlyTermination(ctx, lease, func(params ptypes.Params) sdkmath.LegacyDec {
+		return params.EarlyReclaimSlashFraction
+	})
+	if err != nil {
+		return err
+	}
+
 	blockTime := ctx.BlockTime()
 	deadline := blockTime.Add(lease.Reclamation.Window)
 
 	lease.Reclamation.StartedAt = ctx.BlockHeight()
 	lease.Reclamation.Deadline = deadline.Unix()
-	lease.Reclamation.Reason = msg.Reason
+	lease.Reclamation.Reason = reason
 	lease.State = mv1.LeaseReclaiming
 
 	if err := ms.keepers.Market.SaveLease(ctx, lease); err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := ctx.EventManager().EmitTypedEvent(&mv1.EventLeaseReclaimStarted{
+	return ctx.EventManager().EmitTypedEvent(&mv1.EventLeaseReclaimStarted{
 		ID:       lease.ID,
-		Reason:   msg.Reason,
+		Reason:   reason,
 		Deadline: deadline.Unix(),
-	}); err != nil {
+	})
+}
+
+// slashEarlyTermination slashes the provider's bond by given fraction when the provider
+// terminates the lease before the minimum lease term set in provider params.
+// Slashed amount is deposited into the tenant's deployment escrow account.
+func (ms msgServer) slashEarlyTermination(ctx sdk.Context, lease mv1.Lease, fraction func(ptypes.Params) sdkmath.LegacyDec) error {
+	params, err := ms.keepers.Provider.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if params.MinLeaseTerm <= 0 || ctx.BlockHeight()-lease.CreatedAt >= params.MinLeaseTerm {
+		return nil
+	}
+
+	provider, err := sdk.AccAddressFromBech32(lease.ID.Provider)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := sdk.AccAddressFromBech32(lease.ID.Owner)
+	if err != nil {
+		return err
+	}
+
+	slashed, err := ms.keepers.Provider.Slash(ctx, provider, fraction(params), tenant)
+	if err != nil {
+		return err
+	}
+
+	deposits := make([]etypes.Depositor, 0, len(slashed))
+	for _, coin := range slashed {
+		deposits = append(deposits, etypes.Depositor{
+			Owner:   tenant.String(),
+			Height:  ctx.BlockHeight(),
+			Source:  deposit.SourceBalance,
+			Balance: sdk.NewDecCoinFromCoin(coin),
+		})
+	}
+
+	if len(deposits) == 0 {
+		return nil
+	}
+
+	// slashed amount stays with the tenant if deployment escrow can no longer take deposits
+	if err := ms.keepers.Escrow.AccountDeposit(ctx, lease.ID.DeploymentID().ToEscrowAccountID(), deposits); err != nil {
+		ctx.Logger().With("err", err).Info("error depositing slashed provider bond")
+	}
+
+	telemetry.IncrCounter(1.0, "akash.provider_slashed")
+
+	return nil
+}
+
+// trustedProviderAttributes returns provider attestations, leaving out ones signed by suspended auditors.
+// Attestations of auditors which are not in the registry are kept, so deployments listing them in SignedBy
+// continue to match.
+func (ms msgServer) trustedProviderAttributes(ctx sdk.Context, provider sdk.AccAddress) atypes.AuditedProviders {
+	provAttr, _ := ms.keepers.Audit.GetProviderAttributes(ctx, provider)
+
+	res := make(atypes.AuditedProviders, 0, len(provAttr))
+
+	for _, attr := range provAttr {
+		addr, err := sdk.AccAddressFromBech32(attr.Auditor)
+		if err != nil {
+			continue
+		}
+
+		if auditor, found := ms.keepers.Audit.GetAuditor(ctx, addr); found && auditor.State != atypes.AuditorActive {
+			continue
+		}
+
+		res = append(res, attr)
+	}
+
+	return res
+}
+
+// matchAuditorScopes checks that for each of required scopes provider has been audited by
+// an active registered auditor holding that scope.
+func (ms msgServer) matchAuditorScopes(ctx sdk.Context, scopes []string, provAttr atypes.AuditedProviders) bool {
+next:
+	for _, scope := range scopes {
+		for _, attr := range provAttr {
+			addr, err := sdk.AccAddressFromBech32(attr.Auditor)
+			if err != nil {
+				continue
+			}
+
+			auditor, found := ms.keepers.Audit.GetAuditor(ctx, addr)
+			if found && auditor.State == atypes.AuditorActive && akeeper.AuditorHasScope(auditor, scope) {
+				continue next
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// groupHasOtherOrders returns true if group has any open or active order other than exclude.
+// It is the case while a lease of the group is being migrated to another provider.
+func (ms msgServer) groupHasOtherOrders(ctx sdk.Context, gid dv1.GroupID, exclude mv1.OrderID) bool {
+	found := false
+
+	for _, state := range []mvbeta.Order_State{mvbeta.OrderOpen, mvbeta.OrderActive} {
+		ms.keepers.Market.WithOrdersForGroup(ctx, gid, state, func(order mvbeta.Order) bool {
+			found = !order.ID.Equals(exclude)
+			return found
+		})
+
+		if found {
+			break
+		}
+	}
+
+	return found
+}
+
+// validateBidOffer checks that provider's offer satisfies order's price, resources,
+// attributes and reclamation requirements. It is shared by CreateBid and UpdateBid.
+func (ms msgServer) validateBidOffer(
+	ctx sdk.Context,
+	params mvbeta.Params,
+	order mvbeta.Order,
+	owner string,
+	price sdk.DecCoin,
+	roffer mvbeta.ResourcesOffer,
+	reclaimWindow *time.Duration,
+) (sdk.AccAddress, error) {
+	if err := order.ValidateCanBid(); err != nil {
 		return nil, err
 	}
 
-	return &mvbeta.MsgLeaseStartReclaimResponse{}, nil
+	if !price.IsValid() {
+		return nil, mv1.ErrBidInvalidPrice
+	}
+
+	if order.Price().IsLT(price) {
+		return nil, mv1.ErrBidInvalidPrice
+	}
+
+	if !roffer.MatchGSpec(order.Spec) {
+		return nil, mv1.ErrCapabilitiesMismatch
+	}
+
+	provider, err := sdk.AccAddressFromBech32(owner)
+	if err != nil {
+		return nil, mv1.ErrEmptyProvider
+	}
+
+	prov, found := ms.keepers.Provider.Get(ctx, provider)
+	if !found {
+		return nil, mv1.ErrUnknownProvider
+	}
+
+	if state := ms.keepers.Provider.EffectiveState(ctx, provider); state != ptypes.ProviderAccepting {
+		return nil, fmt.Errorf("%w: provider is %s", mv1.ErrProviderNotAccepting, state)
+	}
+
+	provAttr := ms.trustedProviderAttributes(ctx, provider)
+
+	if !ms.matchAuditorScopes(ctx, order.Spec.Requirements.SignedBy.AnyOfScopes, provAttr) {
+		return nil, mv1.ErrAttributeMismatch
+	}
+
+	provAttr = append([]atypes.AuditedProvider{{
+		Owner:      owner,
+		Attributes: prov.Attributes,
+	}}, provAttr...)
+
+	if !order.MatchRequirements(provAttr) {
+		return nil, mv1.ErrAttributeMismatch
+	}
+
+	if !order.MatchResourcesRequirements(prov.Attributes) {
+		return nil, mv1.ErrCapabilitiesMismatch
+	}
+
+	// Reclamation validation
+	if order.RequiresReclamation() {
+		if reclaimWindow == nil {
+			return nil, mv1.ErrReclamationRequired
+		}
+		if *reclaimWindow < order.Reclamation.MinWindow {
+			return nil, mv1.ErrReclamationWindowTooShort
+		}
+	}
+
+	if reclaimWindow != nil {
+		if *reclaimWindow < params.MinReclamationWindow {
+			return nil, mv1.ErrReclamationWindowInvalid
+		}
+		if *reclaimWindow > params.MaxReclamationWindow {
+			return nil, mv1.ErrReclamationWindowInvalid
+		}
+	}
+
+	return provider, nil
 }
 
 func (ms msgServer) UpdateParams(goCtx context.Context, req *mvbeta.MsgUpdateParams) (*mvbeta.MsgUpdateParamsResponse, error) {
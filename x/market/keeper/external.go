@@ -11,4 +11,5 @@ type EscrowKeeper interface {
 	GetPayment(ctx sdk.Context, id escrowid.Payment) (etypes.Payment, error)
 	AccountClose(ctx sdk.Context, id escrowid.Account) error
 	PaymentClose(ctx sdk.Context, id escrowid.Payment) error
+	PaymentRefund(ctx sdk.Context, id escrowid.Payment, amount sdk.DecCoin) error
 }
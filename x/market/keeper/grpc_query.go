@@ -336,9 +336,10 @@ func (k Querier) Bids(c context.Context, req *types.QueryBidsRequest) (*types.Qu
 	ctx := sdk.UnwrapSDKContext(c)
 
 	// Step 1: Resolve states, resumePK, and iteration path
-	// pathType: 0=state-index, 1=provider-index, 2=owner-prefix
+	// pathType: 0=state-index, 1=provider-index, 2=owner-prefix, 3=state-created-at-index, 4=state-price-index
 	states := make([]byte, 0, 4)
 	var resumePK *keys.BidPrimaryKey
+	var resumeRef []byte
 	pathType := byte(0)
 	var owner string
 	var provider string
@@ -347,7 +348,7 @@ func (k Querier) Bids(c context.Context, req *types.QueryBidsRequest) (*types.Qu
 		// RESUME — all filters ignored, key provides everything
 		var pkBytes, unsolicited []byte
 		var err error
-		states, _, pkBytes, unsolicited, err = query.DecodePaginationKey(req.Pagination.Key)
+		states, resumeRef, pkBytes, unsolicited, err = query.DecodePaginationKey(req.Pagination.Key)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -390,19 +391,40 @@ func (k Querier) Bids(c context.Context, req *types.QueryBidsRequest) (*types.Qu
 			pathType = 1
 			provider = req.Filters.Provider
 		}
+
+		// Sorted paths iterate single state, owner and provider are matched by filters
+		if req.SortBy != types.SortByDefault {
+			if req.Filters.State == "" {
+				return nil, status.Error(codes.InvalidArgument, "invalid request parameters. if sort_by is set, filter.state must be provided")
+			}
+
+			switch req.SortBy {
+			case types.SortByCreatedAt:
+				pathType = 3
+			case types.SortByPrice:
+				pathType = 4
+			default:
+				return nil, status.Error(codes.InvalidArgument, "invalid sort_by value")
+			}
+		}
+	}
+
+	// Step 2: Sorted paths — iterate (state, created at) or (state, price) index
+	if pathType == 3 || pathType == 4 {
+		return k.bidsSortedPath(ctx, req, states, pathType, resumeRef, resumePK)
 	}
 
-	// Step 2: Owner path — iterate primary map with owner prefix
+	// Step 3: Owner path — iterate primary map with owner prefix
 	if pathType == 2 {
 		return k.bidsOwnerPath(ctx, req, states, owner, resumePK)
 	}
 
-	// Step 3: Provider path — iterate provider index
+	// Step 4: Provider path — iterate provider index
 	if pathType == 1 {
 		return k.bidsProviderPath(ctx, req, states, provider, resumePK)
 	}
 
-	// Step 4: State-index path
+	// Step 5: State-index path
 	if len(req.Pagination.Key) == 0 && req.Pagination.Reverse {
 		for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
 			states[i], states[j] = states[j], states[i]
@@ -448,7 +470,7 @@ func (k Querier) bidsOwnerPath(
 			return false, nil
 		}
 
-		if !req.Filters.Accept(bid, bid.State) {
+		if !req.Filters.Accept(bid, bid.State) || !k.acceptBid(ctx, req.Filters, bid) {
 			return false, nil
 		}
 
@@ -542,7 +564,7 @@ func (k Querier) bidsProviderPath(
 			return false
 		}
 
-		if !req.Filters.Accept(bid, bid.State) {
+		if !req.Filters.Accept(bid, bid.State) || !k.acceptBid(ctx, req.Filters, bid) {
 			return false
 		}
 
@@ -638,7 +660,7 @@ func (k Querier) bidsStatePath(
 		count := uint64(0)
 
 		err = indexes.ScanValues(ctx, k.bids, iter, func(bid types.Bid) bool {
-			if !req.Filters.Accept(bid, state) {
+			if !req.Filters.Accept(bid, state) || !k.acceptBid(ctx, req.Filters, bid) {
 				return false
 			}
 
@@ -715,9 +737,10 @@ func (k Querier) Leases(c context.Context, req *types.QueryLeasesRequest) (*type
 	ctx := sdk.UnwrapSDKContext(c)
 
 	// Step 1: Resolve states, resumePK, and iteration path
-	// pathType: 0=state-index, 1=provider-index, 2=owner-prefix
+	// pathType: 0=state-index, 1=provider-index, 2=owner-prefix, 3=state-created-at-index, 4=state-price-index
 	states := make([]byte, 0, 3)
 	var resumePK *keys.LeasePrimaryKey
+	var resumeRef []byte
 	pathType := byte(0)
 	var owner string
 	var provider string
@@ -726,7 +749,7 @@ func (k Querier) Leases(c context.Context, req *types.QueryLeasesRequest) (*type
 		// RESUME — all filters ignored, key provides everything
 		var pkBytes, unsolicited []byte
 		var err error
-		states, _, pkBytes, unsolicited, err = query.DecodePaginationKey(req.Pagination.Key)
+		states, resumeRef, pkBytes, unsolicited, err = query.DecodePaginationKey(req.Pagination.Key)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -769,19 +792,40 @@ func (k Querier) Leases(c context.Context, req *types.QueryLeasesRequest) (*type
 			pathType = 1
 			provider = req.Filters.Provider
 		}
+
+		// Sorted paths iterate single state, owner and provider are matched by filters
+		if req.SortBy != types.SortByDefault {
+			if req.Filters.State == "" {
+				return nil, status.Error(codes.InvalidArgument, "invalid request parameters. if sort_by is set, filter.state must be provided")
+			}
+
+			switch req.SortBy {
+			case types.SortByCreatedAt:
+				pathType = 3
+			case types.SortByPrice:
+				pathType = 4
+			default:
+				return nil, status.Error(codes.InvalidArgument, "invalid sort_by value")
+			}
+		}
+	}
+
+	// Step 2: Sorted paths — iterate (state, created at) or (state, price) index
+	if pathType == 3 || pathType == 4 {
+		return k.leasesSortedPath(ctx, req, states, pathType, resumeRef, resumePK)
 	}
 
-	// Step 2: Owner path — iterate primary map with owner prefix
+	// Step 3: Owner path — iterate primary map with owner prefix
 	if pathType == 2 {
 		return k.leasesOwnerPath(ctx, req, states, owner, resumePK)
 	}
 
-	// Step 3: Provider path — iterate provider index
+	// Step 4: Provider path — iterate provider index
 	if pathType == 1 {
 		return k.leasesProviderPath(ctx, req, states, provider, resumePK)
 	}
 
-	// Step 4: State-index path
+	// Step 5: State-index path
 	if len(req.Pagination.Key) == 0 && req.Pagination.Reverse {
 		for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
 			states[i], states[j] = states[j], states[i]
@@ -827,7 +871,7 @@ func (k Querier) leasesOwnerPath(
 			return false, nil
 		}
 
-		if !req.Filters.Accept(lease, lease.State) {
+		if !req.Filters.Accept(lease, lease.State) || !k.acceptLease(ctx, req.Filters, lease) {
 			return false, nil
 		}
 
@@ -921,7 +965,7 @@ func (k Querier) leasesProviderPath(
 			return false
 		}
 
-		if !req.Filters.Accept(lease, lease.State) {
+		if !req.Filters.Accept(lease, lease.State) || !k.acceptLease(ctx, req.Filters, lease) {
 			return false
 		}
 
@@ -1017,7 +1061,7 @@ func (k Querier) leasesStatePath(
 		count := uint64(0)
 
 		err = indexes.ScanValues(ctx, k.leases, iter, func(lease v1.Lease) bool {
-			if !req.Filters.Accept(lease, state) {
+			if !req.Filters.Accept(lease, state) || !k.acceptLease(ctx, req.Filters, lease) {
 				return false
 			}
 
@@ -1159,6 +1203,37 @@ func (k Querier) Lease(c context.Context, req *types.QueryLeaseRequest) (*types.
 	}, nil
 }
 
+// ProviderSLAHistory returns settled lease SLA epochs of given provider
+func (k Querier) ProviderSLAHistory(c context.Context, req *types.QueryProviderSLAHistoryRequest) (*types.QueryProviderSLAHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if _, err := sdk.AccAddressFromBech32(req.Provider); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	records, pageRes, err := sdkquery.CollectionPaginate(
+		ctx,
+		k.slaRecords,
+		req.Pagination,
+		func(_ keys.LeaseSLARecordKey, record v1.LeaseSLARecord) (v1.LeaseSLARecord, error) {
+			return record, nil
+		},
+		sdkquery.WithCollectionPaginationPairPrefix[string, keys.LeaseEpochKey](req.Provider),
+	)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryProviderSLAHistoryResponse{
+		Records:    records,
+		Pagination: pageRes,
+	}, nil
+}
+
 func (k Querier) Params(ctx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
 	if req == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "empty request")
@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	v1 "pkg.akt.dev/go/node/market/v1"
+	types "pkg.akt.dev/go/node/market/v1beta5"
+)
+
+// acceptBid checks bid against extended filters, which are not covered by BidFilters.Accept
+func (k Querier) acceptBid(ctx sdk.Context, filters types.BidFilters, bid types.Bid) bool {
+	if !acceptPrice(bid.Price, filters.MinPrice, filters.MaxPrice) {
+		return false
+	}
+
+	if !acceptCreatedAt(bid.CreatedAt, filters.MinCreatedAt, filters.MaxCreatedAt) {
+		return false
+	}
+
+	if filters.Reclaimable && bid.ReclamationWindow == nil {
+		return false
+	}
+
+	return k.acceptOrderResources(ctx, bid.ID.OrderID(), filters.Resources)
+}
+
+// acceptLease checks lease against extended filters, which are not covered by LeaseFilters.Accept
+func (k Querier) acceptLease(ctx sdk.Context, filters types.LeaseFilters, lease v1.Lease) bool {
+	if !acceptPrice(lease.Price, filters.MinPrice, filters.MaxPrice) {
+		return false
+	}
+
+	if !acceptCreatedAt(lease.CreatedAt, filters.MinCreatedAt, filters.MaxCreatedAt) {
+		return false
+	}
+
+	if filters.Reclaimable && lease.Reclamation == nil {
+		return false
+	}
+
+	return k.acceptOrderResources(ctx, lease.ID.OrderID(), filters.Resources)
+}
+
+// acceptOrderResources checks group spec of the order requests at least resources set in the filter
+func (k Querier) acceptOrderResources(ctx sdk.Context, id v1.OrderID, filter *types.ResourcesFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	order, found := k.GetOrder(ctx, id)
+	if !found {
+		return false
+	}
+
+	var cpu, memory, gpu uint64
+
+	for _, unit := range order.Spec.Resources {
+		count := uint64(unit.Count)
+
+		if unit.CPU != nil {
+			cpu += unit.CPU.Units.Val.Uint64() * count
+		}
+
+		if unit.Memory != nil {
+			memory += unit.Memory.Quantity.Val.Uint64() * count
+		}
+
+		if unit.GPU != nil {
+			gpu += unit.GPU.Units.Val.Uint64() * count
+		}
+	}
+
+	return cpu >= filter.CPU && memory >= filter.Memory && gpu >= filter.GPU
+}
+
+// acceptPrice checks price is within bounds. Bounds in a different denom never match.
+func acceptPrice(price sdk.DecCoin, minPrice, maxPrice *sdk.DecCoin) bool {
+	if minPrice != nil && (price.Denom != minPrice.Denom || price.Amount.LT(minPrice.Amount)) {
+		return false
+	}
+
+	if maxPrice != nil && (price.Denom != maxPrice.Denom || price.Amount.GT(maxPrice.Amount)) {
+		return false
+	}
+
+	return true
+}
+
+// acceptCreatedAt checks height is within bounds, zero bound is not set
+func acceptCreatedAt(height, minHeight, maxHeight int64) bool {
+	if minHeight > 0 && height < minHeight {
+		return false
+	}
+
+	if maxHeight > 0 && height > maxHeight {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,313 @@
+package keeper
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cosmossdk.io/collections"
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/collections/indexes"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+
+	v1 "pkg.akt.dev/go/node/market/v1"
+	types "pkg.akt.dev/go/node/market/v1beta5"
+
+	"pkg.akt.dev/node/v2/util/query"
+	"pkg.akt.dev/node/v2/x/market/keeper/keys"
+)
+
+// primaryKeyIterator is implemented by iterators of indexes with any reference key
+type primaryKeyIterator[K any] interface {
+	PrimaryKey() (K, error)
+	Next()
+	Valid() bool
+	Close() error
+}
+
+// sortedRange returns range over index entries with reference keys starting at from (inclusive)
+// up to to (exclusive). If resume is set, iteration continues from it (inclusive).
+func sortedRange[R, P any](from, to R, resume *collections.Pair[R, P], reverse bool) *collections.Range[collections.Pair[R, P]] {
+	r := new(collections.Range[collections.Pair[R, P]]).
+		StartInclusive(collections.PairPrefix[R, P](from)).
+		EndExclusive(collections.PairPrefix[R, P](to))
+
+	if resume != nil {
+		if reverse {
+			r.EndInclusive(*resume)
+		} else {
+			r.StartInclusive(*resume)
+		}
+	}
+
+	if reverse {
+		r.Descending()
+	}
+
+	return r
+}
+
+// resumeEntry decodes index reference key of the pagination key and joins it with the primary key
+func resumeEntry[R, P any](codec collcodec.KeyCodec[R], ref []byte, pk *P) (*collections.Pair[R, P], error) {
+	if pk == nil {
+		return nil, nil
+	}
+
+	_, rk, err := codec.Decode(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := collections.Join(rk, *pk)
+
+	return &entry, nil
+}
+
+// encodeRef encodes index reference key for the pagination key
+func encodeRef[R any](codec collcodec.KeyCodec[R], rk R) ([]byte, error) {
+	buf := make([]byte, codec.Size(rk))
+	if _, err := codec.Encode(buf, rk); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// bidsSortedPath iterates bids of a single state via the StateCreatedAt or StatePrice index.
+// Bids sorted by price are grouped by price denom.
+func (k Querier) bidsSortedPath(
+	ctx sdk.Context,
+	req *types.QueryBidsRequest,
+	states []byte,
+	pathType byte,
+	resumeRef []byte,
+	resumePK *keys.BidPrimaryKey,
+) (*types.QueryBidsResponse, error) {
+	state := int32(states[0])
+
+	var iter primaryKeyIterator[keys.BidPrimaryKey]
+
+	switch pathType {
+	case 3:
+		resume, err := resumeEntry(keys.StateHeightKeyCodec, resumeRef, resumePK)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r := sortedRange(collections.Join(state, int64(math.MinInt64)), collections.Join(state+1, int64(math.MinInt64)), resume, req.Pagination.Reverse)
+
+		iter, err = k.bids.Indexes.StateCreatedAt.Iterate(ctx, r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	case 4:
+		resume, err := resumeEntry(keys.StatePriceKeyCodec, resumeRef, resumePK)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r := sortedRange(collections.TriplePrefix[int32, string, string](state), collections.TriplePrefix[int32, string, string](state+1), resume, req.Pagination.Reverse)
+
+		iter, err = k.bids.Indexes.StatePrice.Iterate(ctx, r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination key")
+	}
+
+	var bids []types.QueryBidResponse
+	var nextKey []byte
+	total := uint64(0)
+	offset := req.Pagination.Offset
+	var scanErr error
+
+	err := indexes.ScanValues(ctx, k.bids, iter, func(bid types.Bid) bool {
+		if !req.Filters.Accept(bid, types.Bid_State(state)) || !k.acceptBid(ctx, req.Filters, bid) {
+			return false
+		}
+
+		if offset > 0 {
+			offset--
+			return false
+		}
+
+		if req.Pagination.Limit == 0 {
+			var ref []byte
+			var encErr error
+
+			if pathType == 3 {
+				ref, encErr = encodeRef(keys.StateHeightKeyCodec, collections.Join(state, bid.CreatedAt))
+			} else {
+				ref, encErr = encodeRef(keys.StatePriceKeyCodec, keys.StatePriceKeyFor(state, bid.Price))
+			}
+			if encErr != nil {
+				scanErr = encErr
+				return true
+			}
+
+			pk := keys.BidIDToKey(bid.ID)
+			pkBuf := make([]byte, k.bids.KeyCodec().Size(pk))
+			if _, encErr = k.bids.KeyCodec().Encode(pkBuf, pk); encErr != nil {
+				scanErr = encErr
+				return true
+			}
+
+			nextKey, encErr = query.EncodePaginationKey(states[:1], ref, pkBuf, []byte{pathType})
+			if encErr != nil {
+				scanErr = encErr
+			}
+			return true
+		}
+
+		acct, acctErr := k.ekeeper.GetAccount(ctx, bid.ID.ToEscrowAccountID())
+		if acctErr != nil {
+			scanErr = fmt.Errorf("%w: fetching escrow account for BidID=%s", acctErr, bid.ID)
+			return true
+		}
+
+		bids = append(bids, types.QueryBidResponse{
+			Bid:           bid,
+			EscrowAccount: acct,
+		})
+		req.Pagination.Limit--
+		total++
+
+		return false
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if scanErr != nil {
+		return nil, status.Error(codes.Internal, scanErr.Error())
+	}
+
+	return &types.QueryBidsResponse{
+		Bids: bids,
+		Pagination: &sdkquery.PageResponse{
+			Total:   total,
+			NextKey: nextKey,
+		},
+	}, nil
+}
+
+// leasesSortedPath iterates leases of a single state via the StateCreatedAt or StatePrice index.
+// Leases sorted by price are grouped by price denom.
+func (k Querier) leasesSortedPath(
+	ctx sdk.Context,
+	req *types.QueryLeasesRequest,
+	states []byte,
+	pathType byte,
+	resumeRef []byte,
+	resumePK *keys.LeasePrimaryKey,
+) (*types.QueryLeasesResponse, error) {
+	state := int32(states[0])
+
+	var iter primaryKeyIterator[keys.LeasePrimaryKey]
+
+	switch pathType {
+	case 3:
+		resume, err := resumeEntry(keys.StateHeightKeyCodec, resumeRef, resumePK)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r := sortedRange(collections.Join(state, int64(math.MinInt64)), collections.Join(state+1, int64(math.MinInt64)), resume, req.Pagination.Reverse)
+
+		iter, err = k.leases.Indexes.StateCreatedAt.Iterate(ctx, r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	case 4:
+		resume, err := resumeEntry(keys.StatePriceKeyCodec, resumeRef, resumePK)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r := sortedRange(collections.TriplePrefix[int32, string, string](state), collections.TriplePrefix[int32, string, string](state+1), resume, req.Pagination.Reverse)
+
+		iter, err = k.leases.Indexes.StatePrice.Iterate(ctx, r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination key")
+	}
+
+	var leases []types.QueryLeaseResponse
+	var nextKey []byte
+	total := uint64(0)
+	offset := req.Pagination.Offset
+	var scanErr error
+
+	err := indexes.ScanValues(ctx, k.leases, iter, func(lease v1.Lease) bool {
+		if !req.Filters.Accept(lease, v1.Lease_State(state)) || !k.acceptLease(ctx, req.Filters, lease) {
+			return false
+		}
+
+		if offset > 0 {
+			offset--
+			return false
+		}
+
+		if req.Pagination.Limit == 0 {
+			var ref []byte
+			var encErr error
+
+			if pathType == 3 {
+				ref, encErr = encodeRef(keys.StateHeightKeyCodec, collections.Join(state, lease.CreatedAt))
+			} else {
+				ref, encErr = encodeRef(keys.StatePriceKeyCodec, keys.StatePriceKeyFor(state, lease.Price))
+			}
+			if encErr != nil {
+				scanErr = encErr
+				return true
+			}
+
+			pk := keys.LeaseIDToKey(lease.ID)
+			pkBuf := make([]byte, k.leases.KeyCodec().Size(pk))
+			if _, encErr = k.leases.KeyCodec().Encode(pkBuf, pk); encErr != nil {
+				scanErr = encErr
+				return true
+			}
+
+			nextKey, encErr = query.EncodePaginationKey(states[:1], ref, pkBuf, []byte{pathType})
+			if encErr != nil {
+				scanErr = encErr
+			}
+			return true
+		}
+
+		payment, pmntErr := k.ekeeper.GetPayment(ctx, lease.ID.ToEscrowPaymentID())
+		if pmntErr != nil {
+			scanErr = fmt.Errorf("%w: fetching escrow payment for LeaseID=%s", pmntErr, lease.ID)
+			return true
+		}
+
+		leases = append(leases, types.QueryLeaseResponse{
+			Lease:         lease,
+			EscrowPayment: payment,
+		})
+		req.Pagination.Limit--
+		total++
+
+		return false
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if scanErr != nil {
+		return nil, status.Error(codes.Internal, scanErr.Error())
+	}
+
+	return &types.QueryLeasesResponse{
+		Leases: leases,
+		Pagination: &sdkquery.PageResponse{
+			Total:   total,
+			NextKey: nextKey,
+		},
+	}, nil
+}
@@ -3,8 +3,10 @@ package keeper_test
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -1064,6 +1066,249 @@ func TestGRPCQueryBids(t *testing.T) {
 	}
 }
 
+func TestGRPCQueryBidsSorted(t *testing.T) {
+	suite := setupTest(t)
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		bkeeper := ts.BankKeeper()
+
+		bkeeper.
+			On("SendCoinsFromAccountToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+	})
+
+	prices := make([]sdk.DecCoin, 0, 3)
+	for i := 0; i < 3; i++ {
+		bid, _ := createBid(t, suite.TestSuite)
+		prices = append(prices, bid.Price)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Amount.LT(prices[j].Amount)
+	})
+
+	queryAll := func(req *mvbeta.QueryBidsRequest) []sdk.DecCoin {
+		var res []sdk.DecCoin
+
+		for {
+			resp, err := suite.queryClient.Bids(suite.ctx, req)
+			require.NoError(t, err)
+
+			for _, bid := range resp.Bids {
+				res = append(res, bid.Bid.Price)
+			}
+
+			if len(resp.Pagination.NextKey) == 0 {
+				return res
+			}
+
+			req.Pagination.Key = resp.Pagination.NextKey
+		}
+	}
+
+	res := queryAll(&mvbeta.QueryBidsRequest{
+		Filters:    mvbeta.BidFilters{State: mvbeta.BidOpen.String()},
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.Equal(t, prices, res)
+
+	res = queryAll(&mvbeta.QueryBidsRequest{
+		Filters:    mvbeta.BidFilters{State: mvbeta.BidOpen.String()},
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 1, Reverse: true},
+	})
+	require.Equal(t, []sdk.DecCoin{prices[2], prices[1], prices[0]}, res)
+
+	res = queryAll(&mvbeta.QueryBidsRequest{
+		Filters: mvbeta.BidFilters{
+			State:    mvbeta.BidOpen.String(),
+			MinPrice: &prices[1],
+		},
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.Equal(t, prices[1:], res)
+
+	res = queryAll(&mvbeta.QueryBidsRequest{
+		Filters:    mvbeta.BidFilters{State: mvbeta.BidOpen.String()},
+		SortBy:     mvbeta.SortByCreatedAt,
+		Pagination: &sdkquery.PageRequest{Limit: 2},
+	})
+	require.Len(t, res, 3)
+
+	_, err := suite.queryClient.Bids(suite.ctx, &mvbeta.QueryBidsRequest{SortBy: mvbeta.SortByPrice})
+	require.Error(t, err)
+}
+
+func TestGRPCQueryLeasesSorted(t *testing.T) {
+	suite := setupTest(t)
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		bkeeper := ts.BankKeeper()
+
+		bkeeper.
+			On("SendCoinsFromAccountToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+	})
+
+	// leases created at heights 10, 20 and 30, priced 300, 100 and 200
+	ids := make([]mv1.LeaseID, 0, 3)
+	for i, price := range []int64{300, 100, 200} {
+		id := createLease(t, suite.TestSuite)
+
+		lease, ok := suite.keeper.GetLease(suite.ctx, id)
+		require.True(t, ok)
+
+		lease.CreatedAt = int64(i+1) * 10
+		lease.Price = sdk.NewInt64DecCoin(lease.Price.Denom, price)
+		require.NoError(t, suite.keeper.SaveLease(suite.ctx, lease))
+
+		ids = append(ids, id)
+	}
+
+	queryAll := func(req *mvbeta.QueryLeasesRequest) []mv1.LeaseID {
+		var res []mv1.LeaseID
+
+		for {
+			resp, err := suite.queryClient.Leases(suite.ctx, req)
+			require.NoError(t, err)
+
+			for _, lease := range resp.Leases {
+				res = append(res, lease.Lease.ID)
+			}
+
+			if len(resp.Pagination.NextKey) == 0 {
+				return res
+			}
+
+			req.Pagination.Key = resp.Pagination.NextKey
+		}
+	}
+
+	active := mvbeta.LeaseFilters{State: mv1.LeaseActive.String()}
+
+	res := queryAll(&mvbeta.QueryLeasesRequest{
+		Filters:    active,
+		SortBy:     mvbeta.SortByCreatedAt,
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.Equal(t, ids, res)
+
+	res = queryAll(&mvbeta.QueryLeasesRequest{
+		Filters:    active,
+		SortBy:     mvbeta.SortByCreatedAt,
+		Pagination: &sdkquery.PageRequest{Limit: 1, Reverse: true},
+	})
+	require.Equal(t, []mv1.LeaseID{ids[2], ids[1], ids[0]}, res)
+
+	res = queryAll(&mvbeta.QueryLeasesRequest{
+		Filters:    active,
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.Equal(t, []mv1.LeaseID{ids[1], ids[2], ids[0]}, res)
+
+	res = queryAll(&mvbeta.QueryLeasesRequest{
+		Filters:    active,
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 2, Reverse: true},
+	})
+	require.Equal(t, []mv1.LeaseID{ids[0], ids[2], ids[1]}, res)
+
+	// filters apply to every page of the sorted results
+	res = queryAll(&mvbeta.QueryLeasesRequest{
+		Filters: mvbeta.LeaseFilters{
+			State:        mv1.LeaseActive.String(),
+			MinCreatedAt: 20,
+		},
+		SortBy:     mvbeta.SortByPrice,
+		Pagination: &sdkquery.PageRequest{Limit: 1},
+	})
+	require.Equal(t, []mv1.LeaseID{ids[2], ids[1]}, res)
+
+	_, err := suite.queryClient.Leases(suite.ctx, &mvbeta.QueryLeasesRequest{SortBy: mvbeta.SortByCreatedAt})
+	require.Error(t, err)
+}
+
+func TestGRPCQueryLeasesExtendedFilters(t *testing.T) {
+	suite := setupTest(t)
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		bkeeper := ts.BankKeeper()
+
+		bkeeper.
+			On("SendCoinsFromAccountToModule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+	})
+
+	// leases created at heights 10, 20 and 30, the last one reclaimable
+	ids := make([]mv1.LeaseID, 0, 3)
+	cpu := make(map[mv1.LeaseID]uint64, 3)
+
+	for i := 0; i < 3; i++ {
+		id := createLease(t, suite.TestSuite)
+
+		lease, ok := suite.keeper.GetLease(suite.ctx, id)
+		require.True(t, ok)
+
+		lease.CreatedAt = int64(i+1) * 10
+		if i == 2 {
+			lease.Reclamation = &mv1.Reclamation{Window: time.Hour}
+		}
+		require.NoError(t, suite.keeper.SaveLease(suite.ctx, lease))
+
+		order, ok := suite.keeper.GetOrder(suite.ctx, id.OrderID())
+		require.True(t, ok)
+
+		for _, unit := range order.Spec.Resources {
+			if unit.CPU != nil {
+				cpu[id] += unit.CPU.Units.Val.Uint64() * uint64(unit.Count)
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	query := func(filters mvbeta.LeaseFilters) []mv1.LeaseID {
+		resp, err := suite.queryClient.Leases(suite.ctx, &mvbeta.QueryLeasesRequest{
+			Filters:    filters,
+			Pagination: &sdkquery.PageRequest{Limit: 10},
+		})
+		require.NoError(t, err)
+
+		res := make([]mv1.LeaseID, 0, len(resp.Leases))
+		for _, lease := range resp.Leases {
+			res = append(res, lease.Lease.ID)
+		}
+
+		return res
+	}
+
+	res := query(mvbeta.LeaseFilters{MinCreatedAt: 20})
+	require.ElementsMatch(t, ids[1:], res)
+
+	res = query(mvbeta.LeaseFilters{MaxCreatedAt: 20})
+	require.ElementsMatch(t, ids[:2], res)
+
+	res = query(mvbeta.LeaseFilters{MinCreatedAt: 15, MaxCreatedAt: 25})
+	require.Equal(t, []mv1.LeaseID{ids[1]}, res)
+
+	res = query(mvbeta.LeaseFilters{Reclaimable: true})
+	require.Equal(t, []mv1.LeaseID{ids[2]}, res)
+
+	// resources filter matches leases which order requests at least given resources
+	expected := make([]mv1.LeaseID, 0, 3)
+	for _, id := range ids {
+		if cpu[id] >= cpu[ids[1]] {
+			expected = append(expected, id)
+		}
+	}
+
+	res = query(mvbeta.LeaseFilters{Resources: &mvbeta.ResourcesFilter{CPU: cpu[ids[1]]}})
+	require.ElementsMatch(t, expected, res)
+
+	res = query(mvbeta.LeaseFilters{Resources: &mvbeta.ResourcesFilter{CPU: math.MaxUint64}})
+	require.Empty(t, res)
+}
+
 func TestGRPCQueryLease(t *testing.T) {
 	suite := setupTest(t)
 	suite.PrepareMocks(func(ts *state.TestSuite) {
@@ -17,6 +17,9 @@ type OrderIndexes struct {
 
 	// GroupState indexes orders by (owner, dseq, gseq, state) for WithOrdersForGroup queries
 	GroupState *indexes.Multi[collections.Pair[keys.GroupPartKey, int32], keys.OrderPrimaryKey, mvbeta.Order]
+
+	// StateCreatedAt indexes orders by (state, created at height) for expiry sweeps
+	StateCreatedAt *indexes.Multi[keys.StateHeightKey, keys.OrderPrimaryKey, mvbeta.Order]
 }
 
 // BidIndexes defines the secondary indexes for the bid IndexedMap
@@ -29,6 +32,12 @@ type BidIndexes struct {
 
 	// OrderState indexes bids by (owner, dseq, gseq, oseq, state) for WithBidsForOrder queries
 	OrderState *indexes.Multi[collections.Pair[keys.OrderPrimaryKey, int32], keys.BidPrimaryKey, mvbeta.Bid]
+
+	// StateCreatedAt indexes bids by (state, created at height) for expiry sweeps and sorted queries
+	StateCreatedAt *indexes.Multi[keys.StateHeightKey, keys.BidPrimaryKey, mvbeta.Bid]
+
+	// StatePrice indexes bids by (state, price denom, price amount) for price sorted queries
+	StatePrice *indexes.Multi[keys.StatePriceKey, keys.BidPrimaryKey, mvbeta.Bid]
 }
 
 // LeaseIndexes defines the secondary indexes for the lease IndexedMap
@@ -38,6 +47,12 @@ type LeaseIndexes struct {
 
 	// Provider indexes leases by provider address (covers all states, replaces old reverse keys)
 	Provider *indexes.Multi[string, keys.LeasePrimaryKey, mv1.Lease]
+
+	// StateCreatedAt indexes leases by (state, created at height) for height sorted queries
+	StateCreatedAt *indexes.Multi[keys.StateHeightKey, keys.LeasePrimaryKey, mv1.Lease]
+
+	// StatePrice indexes leases by (state, price denom, price amount) for price sorted queries
+	StatePrice *indexes.Multi[keys.StatePriceKey, keys.LeasePrimaryKey, mv1.Lease]
 }
 
 func (b BidIndexes) IndexesList() []collections.Index[keys.BidPrimaryKey, mvbeta.Bid] {
@@ -45,6 +60,8 @@ func (b BidIndexes) IndexesList() []collections.Index[keys.BidPrimaryKey, mvbeta
 		b.State,
 		b.Provider,
 		b.OrderState,
+		b.StateCreatedAt,
+		b.StatePrice,
 	}
 }
 
@@ -52,6 +69,8 @@ func (l LeaseIndexes) IndexesList() []collections.Index[keys.LeasePrimaryKey, mv
 	return []collections.Index[keys.LeasePrimaryKey, mv1.Lease]{
 		l.State,
 		l.Provider,
+		l.StateCreatedAt,
+		l.StatePrice,
 	}
 }
 
@@ -59,6 +78,7 @@ func (o OrderIndexes) IndexesList() []collections.Index[keys.OrderPrimaryKey, mv
 	return []collections.Index[keys.OrderPrimaryKey, mvbeta.Order]{
 		o.State,
 		o.GroupState,
+		o.StateCreatedAt,
 	}
 }
 
@@ -93,6 +113,16 @@ func NewOrderIndexes(sb *collections.SchemaBuilder) OrderIndexes {
 				return collections.Join(groupPart, int32(order.State)), nil
 			},
 		),
+		StateCreatedAt: indexes.NewMulti(
+			sb,
+			collections.NewPrefix(keys.OrderIndexStateCreatedAtPrefix),
+			"orders_by_state_created_at",
+			keys.StateHeightKeyCodec,
+			keys.OrderPrimaryKeyCodec,
+			func(_ keys.OrderPrimaryKey, order mvbeta.Order) (keys.StateHeightKey, error) {
+				return collections.Join(int32(order.State), order.CreatedAt), nil
+			},
+		),
 	}
 }
 
@@ -138,6 +168,26 @@ func NewBidIndexes(sb *collections.SchemaBuilder) BidIndexes {
 				return collections.Join(orderPart, int32(bid.State)), nil
 			},
 		),
+		StateCreatedAt: indexes.NewMulti(
+			sb,
+			collections.NewPrefix(keys.BidIndexStateCreatedAtPrefix),
+			"bids_by_state_created_at",
+			keys.StateHeightKeyCodec,
+			keys.BidPrimaryKeyCodec,
+			func(_ keys.BidPrimaryKey, bid mvbeta.Bid) (keys.StateHeightKey, error) {
+				return collections.Join(int32(bid.State), bid.CreatedAt), nil
+			},
+		),
+		StatePrice: indexes.NewMulti(
+			sb,
+			collections.NewPrefix(keys.BidIndexStatePricePrefix),
+			"bids_by_state_price",
+			keys.StatePriceKeyCodec,
+			keys.BidPrimaryKeyCodec,
+			func(_ keys.BidPrimaryKey, bid mvbeta.Bid) (keys.StatePriceKey, error) {
+				return keys.StatePriceKeyFor(int32(bid.State), bid.Price), nil
+			},
+		),
 	}
 }
 
@@ -164,5 +214,25 @@ func NewLeaseIndexes(sb *collections.SchemaBuilder) LeaseIndexes {
 				return lease.ID.Provider, nil
 			},
 		),
+		StateCreatedAt: indexes.NewMulti(
+			sb,
+			collections.NewPrefix(keys.LeaseIndexStateCreatedAtPrefix),
+			"leases_by_state_created_at",
+			keys.StateHeightKeyCodec,
+			keys.LeasePrimaryKeyCodec,
+			func(_ keys.LeasePrimaryKey, lease mv1.Lease) (keys.StateHeightKey, error) {
+				return collections.Join(int32(lease.State), lease.CreatedAt), nil
+			},
+		),
+		StatePrice: indexes.NewMulti(
+			sb,
+			collections.NewPrefix(keys.LeaseIndexStatePricePrefix),
+			"leases_by_state_price",
+			keys.StatePriceKeyCodec,
+			keys.LeasePrimaryKeyCodec,
+			func(_ keys.LeasePrimaryKey, lease mv1.Lease) (keys.StatePriceKey, error) {
+				return keys.StatePriceKeyFor(int32(lease.State), lease.Price), nil
+			},
+		),
 	}
 }
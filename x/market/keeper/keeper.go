@@ -25,13 +25,22 @@ type IKeeper interface {
 	Codec() codec.BinaryCodec
 	StoreKey() storetypes.StoreKey
 	CreateOrder(ctx sdk.Context, gid dtypes.GroupID, spec dvbeta.GroupSpec, reclamation *dtypes.DeploymentReclamation) (types.Order, error)
-	CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roffer types.ResourcesOffer, reclaimWindow *time.Duration) (types.Bid, error)
+	CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roffer types.ResourcesOffer, reclaimWindow *time.Duration, sla *mv1.LeaseSLA) (types.Bid, error)
+	UpdateBid(ctx sdk.Context, bid types.Bid) error
 	CreateLease(ctx sdk.Context, bid types.Bid) error
+	CreateMigrationOrder(ctx sdk.Context, lease mv1.Lease, spec dvbeta.GroupSpec, reclamation *dtypes.DeploymentReclamation, overlap time.Duration) (types.Order, error)
+	OnMigrationLeaseCreated(ctx sdk.Context, id mv1.LeaseID) error
+	DueMigratedLeases(ctx sdk.Context, limit int) ([]mv1.LeaseMigrationClose, error)
+	RemoveMigratedLeaseClose(ctx sdk.Context, mc mv1.LeaseMigrationClose) error
+	DeferMigratedLeaseClose(ctx sdk.Context, mc mv1.LeaseMigrationClose, deadline int64) error
+	AttestLeaseUptime(ctx sdk.Context, att mv1.LeaseUptime) error
+	SettleLeaseSLAs(ctx sdk.Context, limit int) error
 	OnOrderMatched(ctx sdk.Context, order types.Order)
 	OnBidMatched(ctx sdk.Context, bid types.Bid)
 	OnBidLost(ctx sdk.Context, bid types.Bid)
 	OnBidClosed(ctx sdk.Context, bid types.Bid) error
 	OnOrderClosed(ctx sdk.Context, order types.Order) error
+	OnOrderTimedOut(ctx sdk.Context, order types.Order) error
 	OnLeaseClosed(ctx sdk.Context, lease mv1.Lease, state mv1.Lease_State, reason mv1.LeaseClosedReason) error
 	OnGroupClosed(ctx sdk.Context, id dtypes.GroupID, state dvbeta.Group_State) error
 	GetOrder(ctx sdk.Context, id mv1.OrderID) (types.Order, bool)
@@ -42,14 +51,30 @@ type IKeeper interface {
 	WithBids(ctx sdk.Context, fn func(types.Bid) bool)
 	WithBidsForOrder(ctx sdk.Context, id mv1.OrderID, state types.Bid_State, fn func(types.Bid) bool)
 	WithLeases(ctx sdk.Context, fn func(mv1.Lease) bool)
+	WithLeasesForProvider(ctx sdk.Context, provider string, fn func(mv1.Lease) bool)
 	WithOrdersForGroup(ctx sdk.Context, id dtypes.GroupID, state types.Order_State, fn func(types.Order) bool)
+	WithOrdersCreatedUntil(ctx sdk.Context, state types.Order_State, height int64, fn func(types.Order) bool)
+	WithBidsCreatedUntil(ctx sdk.Context, state types.Bid_State, height int64, fn func(types.Bid) bool)
+	NextOrdersCreatedUntil(ctx sdk.Context, state types.Order_State, height int64, limit int) ([]types.Order, error)
+	NextBidsCreatedUntil(ctx sdk.Context, state types.Bid_State, height int64, limit int) ([]types.Bid, error)
 	BidCountForOrder(ctx sdk.Context, id mv1.OrderID) uint32
+	OrderCountForState(ctx sdk.Context, state types.Order_State) uint64
+	BidCountForState(ctx sdk.Context, state types.Bid_State) uint64
+	LeaseCountForState(ctx sdk.Context, state mv1.Lease_State) uint64
 	GetParams(ctx sdk.Context) (types.Params, error)
 	SetParams(ctx sdk.Context, params types.Params) error
 	GetAuthority() string
 	SaveOrder(ctx sdk.Context, order types.Order) error
 	SaveBid(ctx sdk.Context, bid types.Bid) error
 	SaveLease(ctx sdk.Context, lease mv1.Lease) error
+	Bids() *collections.IndexedMap[keys.BidPrimaryKey, types.Bid, BidIndexes]
+	Orders() *collections.IndexedMap[keys.OrderPrimaryKey, types.Order, OrderIndexes]
+	Leases() *collections.IndexedMap[keys.LeasePrimaryKey, mv1.Lease, LeaseIndexes]
+	LeaseMigrations() collections.Map[keys.OrderPrimaryKey, keys.LeaseMigrationValue]
+	LeaseMigrationCloses() collections.KeySet[keys.LeaseMigrationCloseKey]
+	LeaseUptimes() collections.Map[keys.LeaseUptimeKey, mv1.LeaseUptime]
+	LeaseSLASettlements() collections.KeySet[keys.LeaseSLASettlementKey]
+	LeaseSLARecords() collections.Map[keys.LeaseSLARecordKey, mv1.LeaseSLARecord]
 }
 
 // Keeper of the market store
@@ -66,6 +91,23 @@ type Keeper struct {
 	orders *collections.IndexedMap[keys.OrderPrimaryKey, types.Order, OrderIndexes]
 	leases *collections.IndexedMap[keys.LeasePrimaryKey, mv1.Lease, LeaseIndexes]
 	Params collections.Item[types.Params]
+
+	// orderExpiryCursor and bidExpiryCursor hold position of the expiry sweeps, they are
+	// not exported to genesis as a sweep starting over from the oldest entry is still correct
+	orderExpiryCursor collections.Item[keys.OrderStateCreatedAtKey]
+	bidExpiryCursor   collections.Item[keys.BidStateCreatedAtKey]
+
+	// migrations maps a replacement order to the lease it replaces
+	migrations collections.Map[keys.OrderPrimaryKey, keys.LeaseMigrationValue]
+	// migrationCloses holds replaced leases scheduled for close, ordered by deadline
+	migrationCloses collections.KeySet[keys.LeaseMigrationCloseKey]
+
+	// uptimes holds uptime attestations of lease SLA epochs pending settlement
+	uptimes collections.Map[keys.LeaseUptimeKey, mv1.LeaseUptime]
+	// slaSettlements holds attested lease SLA epochs, ordered by settlement height
+	slaSettlements collections.KeySet[keys.LeaseSLASettlementKey]
+	// slaRecords holds settled lease SLA epochs grouped by provider
+	slaRecords collections.Map[keys.LeaseSLARecordKey, mv1.LeaseSLARecord]
 }
 
 // NewKeeper creates and returns an instance for Market keeper
@@ -81,6 +123,13 @@ func NewKeeper(cdc codec.BinaryCodec, skey *storetypes.KVStoreKey, ekeeper Escro
 	orders := collections.NewIndexedMap(sb, collections.NewPrefix(keys.OrderPrefixNew), "orders", keys.OrderPrimaryKeyCodec, codec.CollValue[types.Order](cdc), orderIndexes)
 	leases := collections.NewIndexedMap(sb, collections.NewPrefix(keys.LeasePrefixNew), "leases", keys.LeasePrimaryKeyCodec, codec.CollValue[mv1.Lease](cdc), leaseIndexes)
 	params := collections.NewItem(sb, keys.ParamsPrefix, "params", codec.CollValue[types.Params](cdc))
+	orderExpiryCursor := collections.NewItem(sb, collections.NewPrefix(keys.OrderExpiryCursorPrefix), "order_expiry_cursor", keys.OrderStateCreatedAtValueCodec)
+	bidExpiryCursor := collections.NewItem(sb, collections.NewPrefix(keys.BidExpiryCursorPrefix), "bid_expiry_cursor", keys.BidStateCreatedAtValueCodec)
+	migrations := collections.NewMap(sb, collections.NewPrefix(keys.LeaseMigrationPrefix), "lease_migrations", keys.OrderPrimaryKeyCodec, keys.LeaseMigrationValueCodec)
+	migrationCloses := collections.NewKeySet(sb, collections.NewPrefix(keys.LeaseMigrationClosePrefix), "lease_migration_closes", keys.LeaseMigrationCloseKeyCodec)
+	uptimes := collections.NewMap(sb, collections.NewPrefix(keys.LeaseUptimePrefix), "lease_uptimes", keys.LeaseUptimeKeyCodec, codec.CollValue[mv1.LeaseUptime](cdc))
+	slaSettlements := collections.NewKeySet(sb, collections.NewPrefix(keys.LeaseSLASettlementPrefix), "lease_sla_settlements", keys.LeaseSLASettlementKeyCodec)
+	slaRecords := collections.NewMap(sb, collections.NewPrefix(keys.LeaseSLARecordPrefix), "lease_sla_records", keys.LeaseSLARecordKeyCodec, codec.CollValue[mv1.LeaseSLARecord](cdc))
 
 	schema, err := sb.Build()
 	if err != nil {
@@ -97,6 +146,16 @@ func NewKeeper(cdc codec.BinaryCodec, skey *storetypes.KVStoreKey, ekeeper Escro
 		orders:    orders,
 		leases:    leases,
 		Params:    params,
+
+		orderExpiryCursor: orderExpiryCursor,
+		bidExpiryCursor:   bidExpiryCursor,
+
+		migrations:      migrations,
+		migrationCloses: migrationCloses,
+
+		uptimes:        uptimes,
+		slaSettlements: slaSettlements,
+		slaRecords:     slaRecords,
 	}
 
 	return res
@@ -136,6 +195,31 @@ func (k Keeper) Leases() *collections.IndexedMap[keys.LeasePrimaryKey, mv1.Lease
 	return k.leases
 }
 
+// LeaseMigrations returns the pending lease migrations Map for direct access (used by genesis)
+func (k Keeper) LeaseMigrations() collections.Map[keys.OrderPrimaryKey, keys.LeaseMigrationValue] {
+	return k.migrations
+}
+
+// LeaseMigrationCloses returns the migrated lease close schedule KeySet for direct access (used by genesis)
+func (k Keeper) LeaseMigrationCloses() collections.KeySet[keys.LeaseMigrationCloseKey] {
+	return k.migrationCloses
+}
+
+// LeaseUptimes returns the pending uptime attestations Map for direct access (used by genesis)
+func (k Keeper) LeaseUptimes() collections.Map[keys.LeaseUptimeKey, mv1.LeaseUptime] {
+	return k.uptimes
+}
+
+// LeaseSLASettlements returns the lease SLA settlement schedule KeySet for direct access (used by genesis)
+func (k Keeper) LeaseSLASettlements() collections.KeySet[keys.LeaseSLASettlementKey] {
+	return k.slaSettlements
+}
+
+// LeaseSLARecords returns the settled lease SLA records Map for direct access (used by genesis)
+func (k Keeper) LeaseSLARecords() collections.Map[keys.LeaseSLARecordKey, mv1.LeaseSLARecord] {
+	return k.slaRecords
+}
+
 // SetParams sets the x/market module parameters.
 func (k Keeper) SetParams(ctx sdk.Context, p types.Params) error {
 	if err := p.Validate(); err != nil {
@@ -152,12 +236,20 @@ func (k Keeper) GetParams(ctx sdk.Context) (types.Params, error) {
 
 // CreateOrder creates a new order with given group id and specifications. It returns created order
 func (k Keeper) CreateOrder(ctx sdk.Context, gid dtypes.GroupID, spec dvbeta.GroupSpec, reclamation *dtypes.DeploymentReclamation) (types.Order, error) {
+	return k.createOrder(ctx, gid, spec, reclamation, false)
+}
+
+func (k Keeper) createOrder(ctx sdk.Context, gid dtypes.GroupID, spec dvbeta.GroupSpec, reclamation *dtypes.DeploymentReclamation, allowActive bool) (types.Order, error) {
 	oseq := uint32(1)
 	var err error
 
 	k.WithOrdersForGroup(ctx, gid, types.OrderActive, func(_ types.Order) bool {
-		err = mv1.ErrOrderActive
-		return true
+		if !allowActive {
+			err = mv1.ErrOrderActive
+			return true
+		}
+		oseq++
+		return false
 	})
 
 	k.WithOrdersForGroup(ctx, gid, types.OrderOpen, func(_ types.Order) bool {
@@ -210,7 +302,7 @@ func (k Keeper) CreateOrder(ctx sdk.Context, gid dtypes.GroupID, spec dvbeta.Gro
 }
 
 // CreateBid creates a bid for a order with given orderID, price for bid and provider
-func (k Keeper) CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roffer types.ResourcesOffer, reclaimWindow *time.Duration) (types.Bid, error) {
+func (k Keeper) CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roffer types.ResourcesOffer, reclaimWindow *time.Duration, sla *mv1.LeaseSLA) (types.Bid, error) {
 	pk := keys.BidIDToKey(id)
 
 	has, err := k.bids.Has(ctx, pk)
@@ -228,6 +320,7 @@ func (k Keeper) CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roff
 		CreatedAt:         ctx.BlockHeight(),
 		ResourcesOffer:    roffer,
 		ReclamationWindow: reclaimWindow,
+		SLA:               sla,
 	}
 
 	if err := k.bids.Set(ctx, pk, bid); err != nil {
@@ -247,6 +340,41 @@ func (k Keeper) CreateBid(ctx sdk.Context, id mv1.BidID, price sdk.DecCoin, roff
 	return bid, nil
 }
 
+// UpdateBid replaces price, resources offer and reclamation window of an open bid
+func (k Keeper) UpdateBid(ctx sdk.Context, bid types.Bid) error {
+	pk := keys.BidIDToKey(bid.ID)
+
+	curr, err := k.bids.Get(ctx, pk)
+	if err != nil {
+		return mv1.ErrUnknownBid
+	}
+
+	if curr.State != types.BidOpen {
+		return mv1.ErrBidNotOpen
+	}
+
+	// only offer details may change, keep identity and lifecycle fields intact
+	curr.Price = bid.Price
+	curr.ResourcesOffer = bid.ResourcesOffer
+	curr.ReclamationWindow = bid.ReclamationWindow
+
+	if err := k.bids.Set(ctx, pk, curr); err != nil {
+		return fmt.Errorf("failed to update bid: %w", err)
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&mv1.EventBidUpdated{
+			ID:    curr.ID,
+			Price: curr.Price,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateLease creates lease for bid with given bidID.
 // Should only be called by the EndBlock handler or unit tests.
 func (k Keeper) CreateLease(ctx sdk.Context, bid types.Bid) error {
@@ -255,6 +383,7 @@ func (k Keeper) CreateLease(ctx sdk.Context, bid types.Bid) error {
 		State:     mv1.LeaseActive,
 		Price:     bid.Price,
 		CreatedAt: ctx.BlockHeight(),
+		SLA:       bid.SLA,
 	}
 
 	pk := keys.LeaseIDToKey(lease.ID)
@@ -323,6 +452,15 @@ func (k Keeper) OnBidClosed(ctx sdk.Context, bid types.Bid) error {
 
 // OnOrderClosed updates order state to closed
 func (k Keeper) OnOrderClosed(ctx sdk.Context, order types.Order) error {
+	return k.closeOrder(ctx, order, mv1.OrderClosedReasonUnspecified)
+}
+
+// OnOrderTimedOut updates order state to closed after it exceeded the maximum order age
+func (k Keeper) OnOrderTimedOut(ctx sdk.Context, order types.Order) error {
+	return k.closeOrder(ctx, order, mv1.OrderClosedReasonTimeout)
+}
+
+func (k Keeper) closeOrder(ctx sdk.Context, order types.Order, reason mv1.OrderClosedReason) error {
 	if order.State == types.OrderClosed {
 		return nil
 	}
@@ -333,9 +471,15 @@ func (k Keeper) OnOrderClosed(ctx sdk.Context, order types.Order) error {
 
 	k.updateOrder(ctx, order, currState)
 
+	// replacement order closed before it was matched, drop pending migration
+	if err := k.migrations.Remove(ctx, keys.OrderIDToKey(order.ID)); err != nil {
+		return err
+	}
+
 	err := ctx.EventManager().EmitTypedEvent(
 		&mv1.EventOrderClosed{
-			ID: order.ID,
+			ID:     order.ID,
+			Reason: reason,
 		},
 	)
 	if err != nil {
@@ -559,6 +703,97 @@ func (k Keeper) WithBidsForOrder(ctx sdk.Context, id mv1.OrderID, state types.Bi
 	}
 }
 
+// WithLeasesForProvider iterates all leases of given provider
+func (k Keeper) WithLeasesForProvider(ctx sdk.Context, provider string, fn func(mv1.Lease) bool) {
+	iter, err := k.leases.Indexes.Provider.MatchExact(ctx, provider)
+	if err != nil {
+		panic(fmt.Sprintf("WithLeasesForProvider iteration failed: %v", err))
+	}
+
+	err = indexes.ScanValues(ctx, k.leases, iter, func(lease mv1.Lease) bool {
+		return fn(lease)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("WithLeasesForProvider scan failed: %v", err))
+	}
+}
+
+// WithOrdersCreatedUntil iterates orders in given state created at or before given height, oldest first
+func (k Keeper) WithOrdersCreatedUntil(ctx sdk.Context, state types.Order_State, height int64, fn func(types.Order) bool) {
+	iter, err := k.orders.Indexes.StateCreatedAt.Iterate(ctx, stateHeightRange[keys.OrderPrimaryKey](int32(state), height))
+	if err != nil {
+		panic(fmt.Sprintf("WithOrdersCreatedUntil iteration failed: %v", err))
+	}
+
+	err = indexes.ScanValues(ctx, k.orders, iter, func(order types.Order) bool {
+		return fn(order)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("WithOrdersCreatedUntil scan failed: %v", err))
+	}
+}
+
+// WithBidsCreatedUntil iterates bids in given state created at or before given height, oldest first
+func (k Keeper) WithBidsCreatedUntil(ctx sdk.Context, state types.Bid_State, height int64, fn func(types.Bid) bool) {
+	iter, err := k.bids.Indexes.StateCreatedAt.Iterate(ctx, stateHeightRange[keys.BidPrimaryKey](int32(state), height))
+	if err != nil {
+		panic(fmt.Sprintf("WithBidsCreatedUntil iteration failed: %v", err))
+	}
+
+	err = indexes.ScanValues(ctx, k.bids, iter, func(bid types.Bid) bool {
+		return fn(bid)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("WithBidsCreatedUntil scan failed: %v", err))
+	}
+}
+
+// NextOrdersCreatedUntil returns up to limit orders in given state created at or before given height,
+// oldest first, resuming after the last order returned by the previous call. Once the end is reached
+// the next call starts over from the oldest order, so orders the caller failed to move out of the
+// state are retried without holding back the ones created after them.
+func (k Keeper) NextOrdersCreatedUntil(ctx sdk.Context, state types.Order_State, height int64, limit int) ([]types.Order, error) {
+	pks, err := nextCreatedUntil(ctx, k.orders.Indexes.StateCreatedAt, k.orderExpiryCursor, int32(state), height, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]types.Order, 0, len(pks))
+
+	for _, pk := range pks {
+		order, err := k.orders.Get(ctx, pk)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, order)
+	}
+
+	return res, nil
+}
+
+// NextBidsCreatedUntil returns up to limit bids in given state created at or before given height,
+// oldest first, resuming after the last bid returned by the previous call, see NextOrdersCreatedUntil
+func (k Keeper) NextBidsCreatedUntil(ctx sdk.Context, state types.Bid_State, height int64, limit int) ([]types.Bid, error) {
+	pks, err := nextCreatedUntil(ctx, k.bids.Indexes.StateCreatedAt, k.bidExpiryCursor, int32(state), height, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]types.Bid, 0, len(pks))
+
+	for _, pk := range pks {
+		bid, err := k.bids.Get(ctx, pk)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, bid)
+	}
+
+	return res, nil
+}
+
 func (k Keeper) BidCountForOrder(ctx sdk.Context, id mv1.OrderID) uint32 {
 	orderPart := collections.Join4(id.Owner, id.DSeq, id.GSeq, id.OSeq)
 	count := uint32(0)
@@ -578,6 +813,49 @@ func (k Keeper) BidCountForOrder(ctx sdk.Context, id mv1.OrderID) uint32 {
 	return count
 }
 
+// OrderCountForState returns number of orders in given state, walking the state index only
+func (k Keeper) OrderCountForState(ctx sdk.Context, state types.Order_State) uint64 {
+	iter, err := k.orders.Indexes.State.MatchExact(ctx, int32(state))
+	if err != nil {
+		panic(fmt.Sprintf("OrderCountForState failed: %v", err))
+	}
+
+	return countIndexed(iter)
+}
+
+// BidCountForState returns number of bids in given state, walking the state index only
+func (k Keeper) BidCountForState(ctx sdk.Context, state types.Bid_State) uint64 {
+	iter, err := k.bids.Indexes.State.MatchExact(ctx, int32(state))
+	if err != nil {
+		panic(fmt.Sprintf("BidCountForState failed: %v", err))
+	}
+
+	return countIndexed(iter)
+}
+
+// LeaseCountForState returns number of leases in given state, walking the state index only
+func (k Keeper) LeaseCountForState(ctx sdk.Context, state mv1.Lease_State) uint64 {
+	iter, err := k.leases.Indexes.State.MatchExact(ctx, int32(state))
+	if err != nil {
+		panic(fmt.Sprintf("LeaseCountForState failed: %v", err))
+	}
+
+	return countIndexed(iter)
+}
+
+func countIndexed[K any](iter indexes.MultiIterator[int32, K]) uint64 {
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	count := uint64(0)
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+
+	return count
+}
+
 func (k Keeper) updateOrder(ctx sdk.Context, order types.Order, _ types.Order_State) {
 	// IndexedMap.Set automatically updates all indexes:
 	// - removes old index references via lazyOldValue
@@ -610,3 +888,65 @@ func (k Keeper) SaveBid(ctx sdk.Context, bid types.Bid) error {
 func (k Keeper) SaveLease(ctx sdk.Context, lease mv1.Lease) error {
 	return k.leases.Set(ctx, keys.LeaseIDToKey(lease.ID), lease)
 }
+
+// nextCreatedUntil returns primary keys of up to limit StateCreatedAt index entries of given state
+// with height in [0, height], starting after the cursor. The cursor is moved to the last returned
+// entry, or cleared once the end of the range is reached.
+func nextCreatedUntil[PK, V any](
+	ctx sdk.Context,
+	idx *indexes.Multi[keys.StateHeightKey, PK, V],
+	cursor collections.Item[collections.Pair[keys.StateHeightKey, PK]],
+	state int32,
+	height int64,
+	limit int,
+) ([]PK, error) {
+	rng := stateHeightRange[PK](state, height)
+
+	last, err := cursor.Get(ctx)
+	if err == nil {
+		// cursor past the cutoff, e.g. after max age params change, restarts the sweep
+		if last.K1().K1() == state && last.K1().K2() <= height {
+			rng = rng.StartExclusive(last)
+		}
+	} else if !collections.IsNotFound(err) {
+		return nil, err
+	}
+
+	iter, err := idx.Iterate(ctx, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	res := make([]PK, 0, limit)
+
+	for ; iter.Valid() && len(res) < limit; iter.Next() {
+		key, err := iter.FullKey()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, key.K2())
+		last = key
+	}
+
+	if len(res) < limit {
+		return res, cursor.Remove(ctx)
+	}
+
+	return res, cursor.Set(ctx, last)
+}
+
+// stateHeightRange returns a range over StateCreatedAt index entries of given state
+// with height in [0, height]. Empty primary key parts encode to nothing, so the bounds
+// cover every primary key within the (state, height) prefix.
+func stateHeightRange[PK any](state int32, height int64) *collections.Range[collections.Pair[keys.StateHeightKey, PK]] {
+	var pk PK
+
+	return new(collections.Range[collections.Pair[keys.StateHeightKey, PK]]).
+		StartInclusive(collections.Join(collections.Join(state, int64(0)), pk)).
+		EndExclusive(collections.Join(collections.Join(state, height+1), pk))
+}
@@ -49,7 +49,7 @@ func Test_CreateBidWithReclamationWindow(t *testing.T) {
 	bidID := mv1.MakeBidID(order.ID, provider)
 
 	window := 48 * time.Hour
-	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window)
+	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window, nil)
 	require.NoError(t, err)
 	require.NotNil(t, bid.ReclamationWindow)
 	assert.Equal(t, 48*time.Hour, *bid.ReclamationWindow)
@@ -66,7 +66,7 @@ func Test_CreateBidWithoutReclamationWindow(t *testing.T) {
 	roffer := mvbeta.ResourceOfferFromRU(order.Spec.Resources)
 	bidID := mv1.MakeBidID(order.ID, provider)
 
-	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, nil)
+	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, nil, nil)
 	require.NoError(t, err)
 	assert.Nil(t, bid.ReclamationWindow)
 }
@@ -84,7 +84,7 @@ func Test_LeaseReclamationStoredFromBid(t *testing.T) {
 	bidID := mv1.MakeBidID(order.ID, provider)
 	window := 24 * time.Hour
 
-	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window)
+	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window, nil)
 	require.NoError(t, err)
 
 	// Create lease
@@ -125,7 +125,7 @@ func Test_LeaseStartReclaim(t *testing.T) {
 	bidID := mv1.MakeBidID(order.ID, provider)
 	window := 24 * time.Hour
 
-	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window)
+	bid, err := keeper.CreateBid(ctx, bidID, price, roffer, &window, nil)
 	require.NoError(t, err)
 
 	err = keeper.CreateLease(ctx, bid)
@@ -179,6 +179,60 @@ func Test_LeaseForOrder(t *testing.T) {
 	}
 }
 
+func Test_CountForState(t *testing.T) {
+	ctx, keeper, suite := setupKeeper(t)
+
+	createLease(t, suite)
+	createLease(t, suite)
+	createBid(t, suite)
+
+	assert.Equal(t, uint64(1), keeper.OrderCountForState(ctx, mvbeta.OrderOpen))
+	assert.Equal(t, uint64(2), keeper.OrderCountForState(ctx, mvbeta.OrderActive))
+	assert.Equal(t, uint64(1), keeper.BidCountForState(ctx, mvbeta.BidOpen))
+	assert.Equal(t, uint64(2), keeper.BidCountForState(ctx, mvbeta.BidActive))
+	assert.Equal(t, uint64(2), keeper.LeaseCountForState(ctx, mv1.LeaseActive))
+	assert.Equal(t, uint64(0), keeper.LeaseCountForState(ctx, mv1.LeaseClosed))
+}
+
+func Test_NextOrdersCreatedUntil(t *testing.T) {
+	ctx, keeper, suite := setupKeeper(t)
+
+	orders := make([]mv1.OrderID, 0, 3)
+
+	for i := int64(1); i <= 3; i++ {
+		suite.SetBlockHeight(i)
+		order, _ := createOrder(t, suite.Context(), keeper)
+		orders = append(orders, order.ID)
+	}
+
+	ids := func(res []mvbeta.Order) []mv1.OrderID {
+		out := make([]mv1.OrderID, 0, len(res))
+		for _, order := range res {
+			out = append(out, order.ID)
+		}
+		return out
+	}
+
+	// orders left open by the caller do not hold back the ones after them
+	res, err := keeper.NextOrdersCreatedUntil(ctx, mvbeta.OrderOpen, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, orders[:2], ids(res))
+
+	res, err = keeper.NextOrdersCreatedUntil(ctx, mvbeta.OrderOpen, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, orders[2:], ids(res))
+
+	// and are revisited once the sweep starts over
+	res, err = keeper.NextOrdersCreatedUntil(ctx, mvbeta.OrderOpen, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, orders[:2], ids(res))
+
+	// cutoff bounds the sweep
+	res, err = keeper.NextOrdersCreatedUntil(ctx, mvbeta.OrderOpen, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, orders[:1], ids(res))
+}
+
 func Test_OnOrderMatched(t *testing.T) {
 	ctx, keeper, suite := setupKeeper(t)
 	id := createLease(t, suite)
@@ -469,7 +523,7 @@ func createBid(t testing.TB, suite *state.TestSuite) (mvbeta.Bid, mvbeta.Order)
 
 	bidID := mv1.MakeBidID(order.ID, provider)
 
-	bid, err := suite.MarketKeeper().CreateBid(ctx, bidID, price, roffer, nil)
+	bid, err := suite.MarketKeeper().CreateBid(ctx, bidID, price, roffer, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, order.ID, bid.ID.OrderID())
 	assert.Equal(t, price, bid.Price)
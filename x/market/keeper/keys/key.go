@@ -31,6 +31,8 @@ var (
 	OrderPrefixNew                    = []byte{0x11, 0x01}
 	OrderIndexStatePrefix             = []byte{0x11, 0x02}
 	OrderIndexGroupStatePrefix        = []byte{0x11, 0x03}
+	OrderIndexStateCreatedAtPrefix    = []byte{0x11, 0x04}
+	OrderExpiryCursorPrefix           = []byte{0x11, 0x05}
 	OrderStateOpenPrefix              = []byte{OrderStateOpenPrefixID}
 	OrderStateActivePrefix            = []byte{OrderStateActivePrefixID}
 	OrderStateClosedPrefix            = []byte{OrderStateClosedPrefixID}
@@ -40,6 +42,9 @@ var (
 	BidIndexStatePrefix               = []byte{0x12, 0x03}
 	BidIndexProviderPrefix            = []byte{0x12, 0x04}
 	BidIndexOrderStatePrefix          = []byte{0x12, 0x05}
+	BidIndexStateCreatedAtPrefix      = []byte{0x12, 0x06}
+	BidIndexStatePricePrefix          = []byte{0x12, 0x07}
+	BidExpiryCursorPrefix             = []byte{0x12, 0x08}
 	BidStateOpenPrefix                = []byte{BidStateOpenPrefixID}
 	BidStateActivePrefix              = []byte{BidStateActivePrefixID}
 	BidStateLostPrefix                = []byte{BidStateLostPrefixID}
@@ -49,10 +54,17 @@ var (
 	LeasePrefixNew                    = []byte{0x13, 0x02}
 	LeaseIndexStatePrefix             = []byte{0x13, 0x03}
 	LeaseIndexProviderPrefix          = []byte{0x13, 0x04}
+	LeaseIndexStateCreatedAtPrefix    = []byte{0x13, 0x05}
+	LeaseIndexStatePricePrefix        = []byte{0x13, 0x06}
 	LeaseStateActivePrefix            = []byte{LeaseStateActivePrefixID}
 	LeaseStateInsufficientFundsPrefix = []byte{LeaseStateInsufficientFundsPrefixID}
 	LeaseStateClosedPrefix            = []byte{LeaseStateClosedPrefixID}
 	ParamsPrefix                      = []byte{0x14, 0x00}
+	LeaseMigrationPrefix              = []byte{0x15, 0x00}
+	LeaseMigrationClosePrefix         = []byte{0x15, 0x01}
+	LeaseUptimePrefix                 = []byte{0x16, 0x00}
+	LeaseSLASettlementPrefix          = []byte{0x16, 0x01}
+	LeaseSLARecordPrefix              = []byte{0x16, 0x02}
 )
 
 func OrderKey(statePrefix []byte, id mv1.OrderID) ([]byte, error) {
@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/codec"
+)
+
+// LeaseMigrationValue holds the lease being replaced and the overlap (in seconds)
+// both leases are kept running for once the replacement lease is created
+type LeaseMigrationValue = collections.Pair[LeasePrimaryKey, int64]
+
+// LeaseMigrationValueCodec is the value codec for LeaseMigrationValue
+var LeaseMigrationValueCodec = codec.KeyToValueCodec(collections.PairKeyCodec(
+	LeasePrimaryKeyCodec,
+	collections.Int64Key,
+))
+
+// LeaseMigrationCloseKey represents (deadline unix time, lease) of a replaced lease scheduled for close
+type LeaseMigrationCloseKey = collections.Pair[int64, LeasePrimaryKey]
+
+// LeaseMigrationCloseKeyCodec is the key codec for LeaseMigrationCloseKey
+var LeaseMigrationCloseKeyCodec = collections.PairKeyCodec(
+	collections.Int64Key,
+	LeasePrimaryKeyCodec,
+)
@@ -0,0 +1,42 @@
+package keys
+
+import (
+	"strings"
+
+	"cosmossdk.io/collections"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// priceKeyDigits is wide enough to hold any LegacyDec amount in its integer (atto) form
+const priceKeyDigits = 96
+
+// StatePriceKey represents (state, denom, amount) for price-ordered state index lookups
+type StatePriceKey = collections.Triple[int32, string, string]
+
+// StatePriceKeyCodec is the key codec for StatePriceKey
+var StatePriceKeyCodec = collections.TripleKeyCodec(
+	collections.Int32Key,
+	collections.StringKey,
+	collections.StringKey,
+)
+
+// PriceKey returns denom and amount of the price. Amount is zero padded so its lexicographic
+// order matches numeric order of prices within the denom.
+func PriceKey(price sdk.DecCoin) (string, string) {
+	amount := "0"
+	if !price.Amount.IsNil() {
+		amount = price.Amount.BigInt().String()
+	}
+
+	if len(amount) < priceKeyDigits {
+		amount = strings.Repeat("0", priceKeyDigits-len(amount)) + amount
+	}
+
+	return price.Denom, amount
+}
+
+// StatePriceKeyFor returns the (state, denom, amount) key of the price
+func StatePriceKeyFor(state int32, price sdk.DecCoin) StatePriceKey {
+	denom, amount := PriceKey(price)
+	return collections.Join3(state, denom, amount)
+}
@@ -0,0 +1,42 @@
+package keys
+
+import (
+	"cosmossdk.io/collections"
+)
+
+// LeaseEpochKey represents (lease, epoch) of an SLA epoch of a lease
+type LeaseEpochKey = collections.Pair[LeasePrimaryKey, uint64]
+
+// LeaseUptimeKey represents (lease, epoch, signer) of an uptime attestation
+type LeaseUptimeKey = collections.Triple[LeasePrimaryKey, uint64, string]
+
+// LeaseSLASettlementKey represents (settlement height, lease epoch) of an SLA epoch scheduled for settlement
+type LeaseSLASettlementKey = collections.Pair[int64, LeaseEpochKey]
+
+// LeaseSLARecordKey represents (provider, lease epoch) of a settled SLA epoch
+type LeaseSLARecordKey = collections.Pair[string, LeaseEpochKey]
+
+// LeaseEpochKeyCodec is the key codec for LeaseEpochKey
+var LeaseEpochKeyCodec = collections.PairKeyCodec(
+	LeasePrimaryKeyCodec,
+	collections.Uint64Key,
+)
+
+// LeaseUptimeKeyCodec is the key codec for LeaseUptimeKey
+var LeaseUptimeKeyCodec = collections.TripleKeyCodec(
+	LeasePrimaryKeyCodec,
+	collections.Uint64Key,
+	collections.StringKey,
+)
+
+// LeaseSLASettlementKeyCodec is the key codec for LeaseSLASettlementKey
+var LeaseSLASettlementKeyCodec = collections.PairKeyCodec(
+	collections.Int64Key,
+	LeaseEpochKeyCodec,
+)
+
+// LeaseSLARecordKeyCodec is the key codec for LeaseSLARecordKey
+var LeaseSLARecordKeyCodec = collections.PairKeyCodec(
+	collections.StringKey,
+	LeaseEpochKeyCodec,
+)
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/codec"
 )
 
 // OrderPrimaryKey represents the order portion of a BidID: (owner, dseq, gseq, oseq)
@@ -13,6 +14,9 @@ type ProviderPartKey = collections.Pair[string, uint32]
 // GroupPartKey represents (owner, dseq, gseq) for group-based index lookups
 type GroupPartKey = collections.Triple[string, uint64, uint32]
 
+// StateHeightKey represents (state, height) for height-ordered state index lookups
+type StateHeightKey = collections.Pair[int32, int64]
+
 // OrderPrimaryKeyCodec is the key codec for OrderPrimaryKey
 var OrderPrimaryKeyCodec = collections.QuadKeyCodec(
 	collections.StringKey,
@@ -20,3 +24,29 @@ var OrderPrimaryKeyCodec = collections.QuadKeyCodec(
 	collections.Uint32Key,
 	collections.Uint32Key,
 )
+
+// StateHeightKeyCodec is the key codec for StateHeightKey
+var StateHeightKeyCodec = collections.PairKeyCodec(
+	collections.Int32Key,
+	collections.Int64Key,
+)
+
+// OrderStateCreatedAtKey represents full key of the order StateCreatedAt index entry,
+// it is used as position of a sweep over the index
+type OrderStateCreatedAtKey = collections.Pair[StateHeightKey, OrderPrimaryKey]
+
+// BidStateCreatedAtKey represents full key of the bid StateCreatedAt index entry,
+// it is used as position of a sweep over the index
+type BidStateCreatedAtKey = collections.Pair[StateHeightKey, BidPrimaryKey]
+
+// OrderStateCreatedAtValueCodec is the value codec for OrderStateCreatedAtKey
+var OrderStateCreatedAtValueCodec = codec.KeyToValueCodec(collections.PairKeyCodec(
+	StateHeightKeyCodec,
+	OrderPrimaryKeyCodec,
+))
+
+// BidStateCreatedAtValueCodec is the value codec for BidStateCreatedAtKey
+var BidStateCreatedAtValueCodec = codec.KeyToValueCodec(collections.PairKeyCodec(
+	StateHeightKeyCodec,
+	BidPrimaryKeyCodec,
+))
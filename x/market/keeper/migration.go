@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"cosmossdk.io/collections"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "pkg.akt.dev/go/node/deployment/v1"
+	dvbeta "pkg.akt.dev/go/node/deployment/v1beta4"
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	types "pkg.akt.dev/go/node/market/v1beta5"
+
+	"pkg.akt.dev/node/v2/x/market/keeper/keys"
+)
+
+// CreateMigrationOrder creates a replacement order for the group of given active lease.
+// Unlike CreateOrder, it is allowed while the lease's order is active. Once a lease is
+// created for the replacement order, the replaced lease is closed after overlap.
+func (k Keeper) CreateMigrationOrder(ctx sdk.Context, lease mv1.Lease, spec dvbeta.GroupSpec, reclamation *dtypes.DeploymentReclamation, overlap time.Duration) (types.Order, error) {
+	gid := lease.ID.GroupID()
+
+	var err error
+	k.WithOrdersForGroup(ctx, gid, types.OrderOpen, func(_ types.Order) bool {
+		err = fmt.Errorf("%w: lease migration: open order exists", mv1.ErrOrderActive)
+		return true
+	})
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	order, err := k.createOrder(ctx, gid, spec, reclamation, true)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	value := collections.Join(keys.LeaseIDToKey(lease.ID), int64(overlap/time.Second))
+	if err := k.migrations.Set(ctx, keys.OrderIDToKey(order.ID), value); err != nil {
+		return types.Order{}, fmt.Errorf("failed to save lease migration: %w", err)
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&mv1.EventLeaseMigrationStarted{
+			ID:      lease.ID,
+			OrderID: order.ID,
+		},
+	)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	return order, nil
+}
+
+// OnMigrationLeaseCreated schedules the replaced lease to be closed after the overlap
+// if given lease was created for a replacement order. It is a no-op otherwise.
+func (k Keeper) OnMigrationLeaseCreated(ctx sdk.Context, id mv1.LeaseID) error {
+	opk := keys.OrderIDToKey(id.OrderID())
+
+	value, err := k.migrations.Get(ctx, opk)
+	if err != nil {
+		if collections.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := k.migrations.Remove(ctx, opk); err != nil {
+		return err
+	}
+
+	from := keys.KeyToLeaseID(value.K1())
+	deadline := ctx.BlockTime().Add(time.Duration(value.K2()) * time.Second).Unix()
+
+	if err := k.migrationCloses.Set(ctx, collections.Join(deadline, value.K1())); err != nil {
+		return fmt.Errorf("failed to schedule migrated lease close: %w", err)
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&mv1.EventLeaseMigrated{
+			From:     from,
+			To:       id,
+			Deadline: deadline,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DueMigratedLeases returns up to limit replaced leases which overlap deadline
+// has been reached at current block time, earliest deadline first
+func (k Keeper) DueMigratedLeases(ctx sdk.Context, limit int) ([]mv1.LeaseMigrationClose, error) {
+	// empty lease part encodes to nothing, so the bound covers every lease due at current time
+	rng := new(collections.Range[keys.LeaseMigrationCloseKey]).
+		EndExclusive(collections.Join(ctx.BlockTime().Unix()+1, keys.LeasePrimaryKey{}))
+
+	var res []mv1.LeaseMigrationClose
+
+	err := k.migrationCloses.Walk(ctx, rng, func(key keys.LeaseMigrationCloseKey) (bool, error) {
+		res = append(res, mv1.LeaseMigrationClose{
+			ID:       keys.KeyToLeaseID(key.K2()),
+			Deadline: key.K1(),
+		})
+		return len(res) >= limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemoveMigratedLeaseClose removes replaced lease from the close schedule
+func (k Keeper) RemoveMigratedLeaseClose(ctx sdk.Context, mc mv1.LeaseMigrationClose) error {
+	return k.migrationCloses.Remove(ctx, collections.Join(mc.Deadline, keys.LeaseIDToKey(mc.ID)))
+}
+
+// DeferMigratedLeaseClose moves replaced lease scheduled for close to given deadline
+func (k Keeper) DeferMigratedLeaseClose(ctx sdk.Context, mc mv1.LeaseMigrationClose, deadline int64) error {
+	if err := k.RemoveMigratedLeaseClose(ctx, mc); err != nil {
+		return err
+	}
+
+	return k.migrationCloses.Set(ctx, collections.Join(deadline, keys.LeaseIDToKey(mc.ID)))
+}
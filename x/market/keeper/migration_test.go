@@ -0,0 +1,108 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	"pkg.akt.dev/go/testutil"
+)
+
+func Test_LeaseMigration(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+	id := createLease(t, suite)
+
+	lease, ok := keeper.GetLease(suite.Context(), id)
+	require.True(t, ok)
+
+	order, ok := keeper.GetOrder(suite.Context(), id.OrderID())
+	require.True(t, ok)
+
+	// regular orders are rejected while the lease's order is active
+	_, err := keeper.CreateOrder(suite.Context(), id.GroupID(), order.Spec, nil)
+	require.ErrorIs(t, err, mv1.ErrOrderActive)
+
+	norder, err := keeper.CreateMigrationOrder(suite.Context(), lease, order.Spec, nil, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, id.GroupID(), norder.ID.GroupID())
+	require.Equal(t, order.ID.OSeq+1, norder.ID.OSeq)
+	require.Equal(t, mvbeta.OrderOpen, norder.State)
+
+	// only one replacement order at a time
+	_, err = keeper.CreateMigrationOrder(suite.Context(), lease, order.Spec, nil, time.Hour)
+	require.ErrorIs(t, err, mv1.ErrOrderActive)
+
+	bid, err := keeper.CreateBid(
+		suite.Context(),
+		mv1.MakeBidID(norder.ID, testutil.AccAddress(t)),
+		testutil.ACTDecCoinRandom(t),
+		mvbeta.ResourceOfferFromRU(order.Spec.Resources),
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, keeper.CreateLease(suite.Context(), bid))
+
+	ctx := suite.Context().WithBlockTime(time.Unix(1000, 0))
+	require.NoError(t, keeper.OnMigrationLeaseCreated(ctx, bid.ID.LeaseID()))
+
+	testutil.EnsureEvent(t, ctx.EventManager().ABCIEvents(), &mv1.EventLeaseMigrated{
+		From:     id,
+		To:       bid.ID.LeaseID(),
+		Deadline: 1000 + int64(time.Hour/time.Second),
+	})
+
+	// overlap has not elapsed yet
+	due, err := keeper.DueMigratedLeases(ctx.WithBlockTime(time.Unix(1000, 0).Add(time.Hour-time.Second)), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	deadline := 1000 + int64(time.Hour/time.Second)
+
+	due, err = keeper.DueMigratedLeases(ctx.WithBlockTime(time.Unix(1000, 0).Add(time.Hour)), 10)
+	require.NoError(t, err)
+	require.Equal(t, []mv1.LeaseMigrationClose{{ID: id, Deadline: deadline}}, due)
+
+	// deferred leases are due at the new deadline only
+	require.NoError(t, keeper.DeferMigratedLeaseClose(ctx, due[0], deadline+60))
+
+	due, err = keeper.DueMigratedLeases(ctx.WithBlockTime(time.Unix(deadline, 0)), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	due, err = keeper.DueMigratedLeases(ctx.WithBlockTime(time.Unix(deadline+60, 0)), 10)
+	require.NoError(t, err)
+	require.Equal(t, []mv1.LeaseMigrationClose{{ID: id, Deadline: deadline + 60}}, due)
+
+	// removed leases are no longer scheduled
+	require.NoError(t, keeper.RemoveMigratedLeaseClose(ctx, due[0]))
+
+	due, err = keeper.DueMigratedLeases(ctx.WithBlockTime(time.Unix(1000, 0).Add(2*time.Hour)), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func Test_LeaseMigrationOrderClosed(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+	id := createLease(t, suite)
+
+	lease, ok := keeper.GetLease(suite.Context(), id)
+	require.True(t, ok)
+
+	order, ok := keeper.GetOrder(suite.Context(), id.OrderID())
+	require.True(t, ok)
+
+	norder, err := keeper.CreateMigrationOrder(suite.Context(), lease, order.Spec, nil, time.Hour)
+	require.NoError(t, err)
+
+	// closing the replacement order drops the pending migration
+	require.NoError(t, keeper.OnOrderClosed(suite.Context(), norder))
+	require.NoError(t, keeper.OnMigrationLeaseCreated(suite.Context(), mv1.MakeBidID(norder.ID, testutil.AccAddress(t)).LeaseID()))
+
+	due, err := keeper.DueMigratedLeases(suite.Context().WithBlockTime(time.Now().Add(24*time.Hour)), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
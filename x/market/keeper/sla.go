@@ -0,0 +1,230 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/collections"
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	types "pkg.akt.dev/go/node/market/v1beta5"
+
+	"pkg.akt.dev/node/v2/x/market/keeper/keys"
+)
+
+// slaBasisPoints is the denominator of SLA uptime and refund values
+const slaBasisPoints = 10000
+
+// AttestLeaseUptime records uptime attestation of given lease SLA epoch and schedules
+// the epoch for settlement once its attestation window, one epoch long, has passed.
+// Attestation of the same signer for the same epoch replaces the previous one.
+func (k Keeper) AttestLeaseUptime(ctx sdk.Context, att mv1.LeaseUptime) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if params.SLAEpochBlocks <= 0 {
+		return fmt.Errorf("%w: lease sla epochs are disabled", mv1.ErrInvalidUptimeAttestation)
+	}
+
+	lkey := keys.LeaseIDToKey(att.ID)
+	att.Height = ctx.BlockHeight()
+
+	if err := k.uptimes.Set(ctx, collections.Join3(lkey, att.Epoch, att.Signer), att); err != nil {
+		return fmt.Errorf("failed to save uptime attestation: %w", err)
+	}
+
+	settleAt := int64(att.Epoch+2) * params.SLAEpochBlocks
+	if err := k.slaSettlements.Set(ctx, collections.Join(settleAt, collections.Join(lkey, att.Epoch))); err != nil {
+		return fmt.Errorf("failed to schedule lease sla settlement: %w", err)
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&mv1.EventLeaseUptimeAttested{
+			ID:     att.ID,
+			Epoch:  att.Epoch,
+			Signer: att.Signer,
+			Uptime: att.Uptime,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SettleLeaseSLAs settles up to limit lease SLA epochs which attestation window has passed.
+// Refund is requested from the lease's escrow payment when uptime attested by an auditor is
+// below the lease SLA, and the outcome is recorded in the provider's SLA history. Epochs
+// attested by the tenant only are recorded, but never refunded, as the tenant would be
+// the one to benefit from reporting a downtime.
+func (k Keeper) SettleLeaseSLAs(ctx sdk.Context, limit int) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	rng := collections.NewPrefixUntilPairRange[int64, keys.LeaseEpochKey](ctx.BlockHeight())
+
+	var due []keys.LeaseSLASettlementKey
+
+	err = k.slaSettlements.Walk(ctx, rng, func(key keys.LeaseSLASettlementKey) (bool, error) {
+		due = append(due, key)
+		return len(due) >= limit, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range due {
+		if err := k.slaSettlements.Remove(ctx, key); err != nil {
+			return err
+		}
+
+		if err := k.settleLeaseSLA(ctx, params, key.K2()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k Keeper) settleLeaseSLA(ctx sdk.Context, params types.Params, key keys.LeaseEpochKey) error {
+	uptime, audited, attested, err := k.popLeaseEpochUptime(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	lease, err := k.leases.Get(ctx, key.K1())
+	if err != nil {
+		if collections.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !attested || lease.SLA == nil {
+		return nil
+	}
+
+	record := mv1.LeaseSLARecord{
+		ID:     lease.ID,
+		Epoch:  key.K2(),
+		Uptime: uptime,
+		Target: lease.SLA.Uptime,
+		Refund: sdk.NewDecCoin(lease.Price.Denom, sdkmath.ZeroInt()),
+		Height: ctx.BlockHeight(),
+	}
+
+	if audited && uptime < lease.SLA.Uptime {
+		blocks := leaseEpochBlocks(lease, key.K2(), params.SLAEpochBlocks)
+
+		amount := lease.Price.Amount.
+			MulInt64(blocks).
+			MulInt64(int64(lease.SLA.Refund)).
+			QuoInt64(slaBasisPoints)
+
+		refund := sdk.NewDecCoinFromDec(lease.Price.Denom, amount)
+
+		if refund.IsPositive() {
+			// payment of a lease closed in the meantime is settled already, nothing is left to refund
+			if err := k.ekeeper.PaymentRefund(ctx, lease.ID.ToEscrowPaymentID(), refund); err != nil {
+				ctx.Logger().With("err", err).Info("error refunding lease sla breach")
+			} else {
+				record.Refund = refund
+			}
+		}
+	}
+
+	if err := k.slaRecords.Set(ctx, collections.Join(lease.ID.Provider, key), record); err != nil {
+		return fmt.Errorf("failed to save lease sla record: %w", err)
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(
+		&mv1.EventLeaseSLASettled{
+			ID:     record.ID,
+			Epoch:  record.Epoch,
+			Uptime: record.Uptime,
+			Target: record.Target,
+			Refund: record.Refund,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// popLeaseEpochUptime removes attestations of given lease epoch and returns effective uptime,
+// whether it has been attested by an auditor, and whether it has been attested at all.
+// Auditor attestations take precedence over tenant's one, and the lowest auditor attestation counts.
+func (k Keeper) popLeaseEpochUptime(ctx sdk.Context, key keys.LeaseEpochKey) (uint32, bool, bool, error) {
+	rng := collections.NewSuperPrefixedTripleRange[keys.LeasePrimaryKey, uint64, string](key.K1(), key.K2())
+
+	var atts []mv1.LeaseUptime
+	var pks []keys.LeaseUptimeKey
+
+	err := k.uptimes.Walk(ctx, rng, func(pk keys.LeaseUptimeKey, att mv1.LeaseUptime) (bool, error) {
+		pks = append(pks, pk)
+		atts = append(atts, att)
+		return false, nil
+	})
+	if err != nil {
+		return 0, false, false, err
+	}
+
+	for _, pk := range pks {
+		if err := k.uptimes.Remove(ctx, pk); err != nil {
+			return 0, false, false, err
+		}
+	}
+
+	var tenant *uint32
+	var auditor *uint32
+
+	for _, att := range atts {
+		uptime := att.Uptime
+
+		if !att.Auditor {
+			tenant = &uptime
+			continue
+		}
+
+		if auditor == nil || uptime < *auditor {
+			auditor = &uptime
+		}
+	}
+
+	switch {
+	case auditor != nil:
+		return *auditor, true, true, nil
+	case tenant != nil:
+		return *tenant, false, true, nil
+	}
+
+	return 0, false, false, nil
+}
+
+// leaseEpochBlocks returns number of blocks of given SLA epoch the lease was running for
+func leaseEpochBlocks(lease mv1.Lease, epoch uint64, epochBlocks int64) int64 {
+	start := int64(epoch) * epochBlocks
+	end := start + epochBlocks
+
+	if lease.CreatedAt > start {
+		start = lease.CreatedAt
+	}
+
+	if lease.ClosedOn > 0 && lease.ClosedOn < end {
+		end = lease.ClosedOn
+	}
+
+	if end <= start {
+		return 0
+	}
+
+	return end - start
+}
@@ -0,0 +1,135 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	mv1 "pkg.akt.dev/go/node/market/v1"
+	mvbeta "pkg.akt.dev/go/node/market/v1beta5"
+	"pkg.akt.dev/go/testutil"
+)
+
+func Test_LeaseSLASettlement(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+	id := createLease(t, suite)
+
+	params, err := keeper.GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.SLAEpochBlocks = 10
+	require.NoError(t, keeper.SetParams(suite.Context(), params))
+
+	lease, ok := keeper.GetLease(suite.Context(), id)
+	require.True(t, ok)
+
+	lease.CreatedAt = 0
+	lease.SLA = &mv1.LeaseSLA{
+		Uptime: 9900,
+		Refund: 5000,
+	}
+	require.NoError(t, keeper.SaveLease(suite.Context(), lease))
+
+	ctx := suite.Context().WithBlockHeight(12)
+
+	require.NoError(t, keeper.AttestLeaseUptime(ctx, mv1.LeaseUptime{
+		ID:     id,
+		Epoch:  0,
+		Signer: id.Owner,
+		Uptime: 5000,
+	}))
+
+	auditor := testutil.AccAddress(t).String()
+
+	require.NoError(t, keeper.AttestLeaseUptime(ctx, mv1.LeaseUptime{
+		ID:      id,
+		Epoch:   0,
+		Signer:  auditor,
+		Auditor: true,
+		Uptime:  9800,
+	}))
+
+	testutil.EnsureEvent(t, ctx.EventManager().ABCIEvents(), &mv1.EventLeaseUptimeAttested{
+		ID:     id,
+		Epoch:  0,
+		Signer: auditor,
+		Uptime: 9800,
+	})
+
+	querier := keeper.NewQuerier()
+	history := func(ctx sdk.Context) []mv1.LeaseSLARecord {
+		res, err := querier.ProviderSLAHistory(ctx, &mvbeta.QueryProviderSLAHistoryRequest{Provider: id.Provider})
+		require.NoError(t, err)
+		return res.Records
+	}
+
+	// attestation window of the epoch is still open
+	require.NoError(t, keeper.SettleLeaseSLAs(ctx.WithBlockHeight(19), 10))
+	require.Empty(t, history(ctx))
+
+	sctx := ctx.WithBlockHeight(20)
+	require.NoError(t, keeper.SettleLeaseSLAs(sctx, 10))
+
+	// auditor attestation takes precedence over the tenant's one
+	expected := mv1.LeaseSLARecord{
+		ID:     id,
+		Epoch:  0,
+		Uptime: 9800,
+		Target: 9900,
+		Refund: sdk.NewDecCoinFromDec(lease.Price.Denom, lease.Price.Amount.MulInt64(5)),
+		Height: 20,
+	}
+
+	require.Equal(t, []mv1.LeaseSLARecord{expected}, history(sctx))
+
+	// settled epochs are not settled again
+	require.NoError(t, keeper.SettleLeaseSLAs(ctx.WithBlockHeight(30), 10))
+	require.Len(t, history(sctx), 1)
+
+	// epoch attested by the tenant only is recorded, but never refunded
+	require.NoError(t, keeper.AttestLeaseUptime(ctx.WithBlockHeight(22), mv1.LeaseUptime{
+		ID:     id,
+		Epoch:  1,
+		Signer: id.Owner,
+		Uptime: 0,
+	}))
+
+	sctx = ctx.WithBlockHeight(30)
+	require.NoError(t, keeper.SettleLeaseSLAs(sctx, 10))
+
+	require.Equal(t, []mv1.LeaseSLARecord{
+		expected,
+		{
+			ID:     id,
+			Epoch:  1,
+			Uptime: 0,
+			Target: 9900,
+			Refund: sdk.NewDecCoin(lease.Price.Denom, sdkmath.ZeroInt()),
+			Height: 30,
+		},
+	}, history(sctx))
+}
+
+func Test_LeaseSLADisabled(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+	id := createLease(t, suite)
+
+	params, err := keeper.GetParams(suite.Context())
+	require.NoError(t, err)
+
+	params.SLAEpochBlocks = 0
+	require.NoError(t, keeper.SetParams(suite.Context(), params))
+
+	err = keeper.AttestLeaseUptime(suite.Context(), mv1.LeaseUptime{
+		ID:     id,
+		Signer: id.Owner,
+		Uptime: 10000,
+	})
+	require.ErrorIs(t, err, mv1.ErrInvalidUptimeAttestation)
+
+	res, err := keeper.NewQuerier().ProviderSLAHistory(suite.Context(), &mvbeta.QueryProviderSLAHistoryRequest{Provider: id.Provider})
+	require.NoError(t, err)
+	require.Empty(t, res.Records)
+}
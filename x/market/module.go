@@ -35,6 +35,7 @@ var (
 	_ module.HasGenesisBasics = AppModuleBasic{}
 
 	_ appmodule.AppModule        = AppModule{}
+	_ appmodule.HasEndBlocker    = AppModule{}
 	_ module.HasConsensusVersion = AppModule{}
 	_ module.HasGenesis          = AppModule{}
 	_ module.HasServices         = AppModule{}
@@ -159,8 +160,8 @@ func (am AppModule) BeginBlock(_ context.Context) error {
 
 // EndBlock returns the end blocker for the market module. It returns no validator
 // updates.
-func (am AppModule) EndBlock(_ context.Context) error {
-	return nil
+func (am AppModule) EndBlock(ctx context.Context) error {
+	return handler.EndBlocker(sdk.UnwrapSDKContext(ctx), am.keepers)
 }
 
 // InitGenesis performs genesis initialization for the market module. It returns
@@ -180,7 +181,7 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
 func (am AppModule) ConsensusVersion() uint64 {
-	return 9
+	return 10
 }
 
 // AppModuleSimulation functions
@@ -18,6 +18,11 @@ func RandomizedGenState(simState *module.SimulationState) {
 			OrderMaxBids:         20,
 			MinReclamationWindow: mvbeta.DefaultMinReclamationWindow,
 			MaxReclamationWindow: mvbeta.DefaultMaxReclamationWindow,
+			BidMaxAge:            mvbeta.DefaultBidMaxAge,
+			OrderMaxAge:          mvbeta.DefaultOrderMaxAge,
+
+			MaxLeaseMigrationOverlap: mvbeta.DefaultMaxLeaseMigrationOverlap,
+			SLAEpochBlocks:           mvbeta.DefaultSLAEpochBlocks,
 		},
 	}
 
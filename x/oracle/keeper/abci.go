@@ -11,6 +11,8 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 
 	types "pkg.akt.dev/go/node/oracle/v2"
+
+	"pkg.akt.dev/node/v2/util/metrics"
 )
 
 // BeginBlocker checks if prices are being updated and sources do not deviate from each other
@@ -99,6 +101,8 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 		return sortedDIDs[i].BaseDenom < sortedDIDs[j].BaseDenom
 	})
 
+	var aggregated, unhealthy int
+
 	for _, did := range sortedDIDs {
 		sources := latestByDenom[did]
 		// Phase 2: check staleness using each source's actual latest price
@@ -158,7 +162,13 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 
 		health := k.setPriceHealth(sctx, params, allSourceIDs, aggregatedPrice)
 
+		if !health.IsHealthy {
+			unhealthy++
+		}
+
 		if health.IsHealthy && len(latestPrices) > 0 {
+			aggregated++
+
 			err = k.aggregatedPrices.Set(sctx, did, aggregatedPrice)
 			if err != nil {
 				sctx.Logger().Error("set aggregated price", "reason", err.Error())
@@ -168,6 +178,9 @@ func (k *keeper) EndBlocker(ctx context.Context) error {
 		}
 	}
 
+	metrics.AddEndBlockerProcessed(types.ModuleName, "prices_aggregated", aggregated)
+	metrics.AddEndBlockerProcessed(types.ModuleName, "prices_unhealthy", unhealthy)
+
 	err = sctx.EventManager().EmitTypedEvents(evts...)
 	if err != nil {
 		sctx.Logger().Error("failed to emit oracle price status change event", "error", err)
@@ -42,6 +42,7 @@ type Keeper interface {
 	GetAggregatedPrice(ctx sdk.Context, denom string) (sdkmath.LegacyDec, error)
 	SetAggregatedPrice(sdk.Context, types.DataID, types.AggregatedPrice) error
 	SetPriceHealth(sdk.Context, types.DataID, types.PriceHealth) error
+	WithAggregatedPrices(sdk.Context, func(types.DataID, types.AggregatedPrice, types.PriceHealth) bool) error
 
 	InitGenesis(ctx sdk.Context, data *types.GenesisState)
 	ExportGenesis(ctx sdk.Context) *types.GenesisState
@@ -537,6 +538,19 @@ func (k *keeper) SetPriceHealth(ctx sdk.Context, id types.DataID, health types.P
 	return k.pricesHealth.Set(ctx, id, health)
 }
 
+// WithAggregatedPrices iterates aggregated prices along with their health.
+// Health is zero value if it has not been recorded for the price yet.
+func (k *keeper) WithAggregatedPrices(ctx sdk.Context, fn func(types.DataID, types.AggregatedPrice, types.PriceHealth) bool) error {
+	return k.aggregatedPrices.Walk(ctx, nil, func(id types.DataID, price types.AggregatedPrice) (bool, error) {
+		health, err := k.pricesHealth.Get(ctx, id)
+		if err != nil && !errors.Is(err, collections.ErrNotFound) {
+			return true, err
+		}
+
+		return fn(id, price, health), nil
+	})
+}
+
 func (k *keeper) AddOnSetParamsHook(hook SetParamsHook) Keeper {
 	k.hooks.onSetParams = append(k.hooks.onSetParams, hook)
 
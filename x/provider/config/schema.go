@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	ptypes "pkg.akt.dev/go/node/provider/v1beta4"
+	tattr "pkg.akt.dev/go/node/types/attributes/v1"
+)
+
+const (
+	schemaWildcard = "*"
+)
+
+var (
+	ErrUnknownAttribute      = errors.New("provider: unknown attribute")
+	ErrInvalidAttributeValue = errors.New("provider: invalid attribute value")
+)
+
+// ValidateAttributes checks attributes against the attribute schema.
+// Attributes which keys are not defined by the schema are accepted unless schema is strict.
+func ValidateAttributes(schema ptypes.AttributeSchema, attrs tattr.Attributes) error {
+	for _, attr := range attrs {
+		def, found := lookupAttributeDefinition(schema.Definitions, attr.Key)
+		if !found {
+			if schema.Strict {
+				return fmt.Errorf("%w: %s", ErrUnknownAttribute, attr.Key)
+			}
+
+			continue
+		}
+
+		if err := validateAttributeValue(def, attr.Value); err != nil {
+			return fmt.Errorf("%w: %s=%s: %s", ErrInvalidAttributeValue, attr.Key, attr.Value, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// lookupAttributeDefinition returns definition matching the key. Exact key definitions take precedence over
+// wildcard ones, and of the wildcards the one with the longest prefix wins.
+func lookupAttributeDefinition(defs []ptypes.AttributeDefinition, key string) (ptypes.AttributeDefinition, bool) {
+	var res ptypes.AttributeDefinition
+	found := false
+	matched := -1
+
+	for _, def := range defs {
+		if def.Key == key {
+			return def, true
+		}
+
+		prefix, wildcard := strings.CutSuffix(def.Key, schemaWildcard)
+		if wildcard && strings.HasPrefix(key, prefix) && len(prefix) > matched {
+			res = def
+			found = true
+			matched = len(prefix)
+		}
+	}
+
+	return res, found
+}
+
+func validateAttributeValue(def ptypes.AttributeDefinition, value string) error {
+	switch def.Type {
+	case ptypes.AttributeTypeString:
+		if value == "" {
+			return errors.New("value must not be empty")
+		}
+	case ptypes.AttributeTypeBool:
+		if value != "true" && value != "false" {
+			return errors.New("value must be either true or false")
+		}
+	case ptypes.AttributeTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return errors.New("value must be an integer")
+		}
+	case ptypes.AttributeTypeEnum:
+		if !slices.Contains(def.Values, value) {
+			return fmt.Errorf("value must be one of %s", strings.Join(def.Values, ", "))
+		}
+	case ptypes.AttributeTypeQuantity:
+		return validateQuantity(def.Units, value)
+	default:
+		return fmt.Errorf("unsupported attribute type %s", def.Type)
+	}
+
+	return nil
+}
+
+// validateQuantity checks value is a non-negative number followed by one of allowed units, e.g. 80Gi.
+// Number without unit is valid only if the definition has no units.
+func validateQuantity(units []string, value string) error {
+	idx := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+
+	num, unit := value, ""
+	if idx >= 0 {
+		num, unit = value[:idx], value[idx:]
+	}
+
+	if _, err := strconv.ParseFloat(num, 64); err != nil || strings.HasPrefix(num, ".") {
+		return errors.New("value must be a non-negative number followed by unit")
+	}
+
+	if len(units) == 0 {
+		if unit != "" {
+			return errors.New("value must not have unit")
+		}
+
+		return nil
+	}
+
+	if !slices.Contains(units, unit) {
+		return fmt.Errorf("unit must be one of %s", strings.Join(units, ", "))
+	}
+
+	return nil
+}
@@ -29,7 +29,7 @@ func ValidateGenesis(data *types.GenesisState) error {
 
 	}
 
-	return nil
+	return data.Params.Validate()
 }
 
 // InitGenesis initiate genesis state and return updated validator details
@@ -51,26 +51,116 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *types.GenesisState)
 
 		store.Set(key, cdc.MustMarshal(&record))
 	}
+
+	if err := kpr.SetParams(ctx, data.Params); err != nil {
+		panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+	}
+
+	for _, record := range data.Bonds {
+		owner, err := sdk.AccAddressFromBech32(record.Owner)
+		if err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+
+		if err := kpr.SetBond(ctx, owner, record.Amount); err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+	}
+
+	for _, record := range data.Inventories {
+		owner, err := sdk.AccAddressFromBech32(record.Owner)
+		if err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+
+		if err := kpr.UpdateInventory(ctx, owner, record.Inventory, record.Timestamp); err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+	}
+
+	// draining providers queued for reclamation of their leases
+	drains := make(map[string]bool, len(data.Drains))
+	for _, owner := range data.Drains {
+		drains[owner] = true
+	}
+
+	for _, record := range data.Statuses {
+		owner, err := sdk.AccAddressFromBech32(record.Owner)
+		if err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+
+		if err := kpr.SetStatus(ctx, owner, record, drains[record.Owner]); err != nil {
+			panic(fmt.Sprintf("provider genesis init: %s", err.Error()))
+		}
+	}
 }
 
 // ExportGenesis returns genesis state as raw bytes for the provider module
 func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *types.GenesisState {
 	var providers []types.Provider
+	var statuses []types.ProviderStatus
 
 	k.WithProviders(ctx, func(provider types.Provider) bool {
 		providers = append(providers, provider)
+
+		owner, err := sdk.AccAddressFromBech32(provider.Owner)
+		if err != nil {
+			panic(err)
+		}
+
+		if status, found := k.GetStatus(ctx, owner); found {
+			statuses = append(statuses, status)
+		}
+
+		return false
+	})
+
+	var bonds []types.ProviderBond
+
+	k.WithBonds(ctx, func(owner sdk.AccAddress, bond sdk.Coin) bool {
+		bonds = append(bonds, types.ProviderBond{
+			Owner:  owner.String(),
+			Amount: bond,
+		})
 		return false
 	})
 
+	var inventories []types.ProviderInventory
+
+	k.WithInventories(ctx, func(inv types.ProviderInventory) bool {
+		inventories = append(inventories, inv)
+		return false
+	})
+
+	var drains []string
+
+	k.WithDrainingProviders(ctx, func(owner sdk.AccAddress) bool {
+		drains = append(drains, owner.String())
+		return false
+	})
+
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		panic(err)
+	}
+
 	return &types.GenesisState{
-		Providers: providers,
+		Providers:   providers,
+		Bonds:       bonds,
+		Inventories: inventories,
+		Statuses:    statuses,
+		Drains:      drains,
+		Params:      params,
 	}
 }
 
 // DefaultGenesisState returns default genesis state as raw bytes for the provider
 // module.
 func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{}
+	return &types.GenesisState{
+		Params: types.DefaultParams(),
+	}
 }
 
 // GetGenesisStateFromAppState returns x/provider GenesisState given raw application
@@ -0,0 +1,73 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+	"pkg.akt.dev/go/sdkutil"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/testutil/state"
+	"pkg.akt.dev/node/v2/x/provider"
+)
+
+func TestGenesisBonds(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+
+	data := &types.GenesisState{
+		Params: types.DefaultParams(),
+		Bonds: []types.ProviderBond{
+			{
+				Owner:  testutil.AccAddress(t).String(),
+				Amount: sdk.NewInt64Coin(sdkutil.DenomUakt, 1000),
+			},
+			{
+				Owner:  testutil.AccAddress(t).String(),
+				Amount: sdk.NewInt64Coin(sdkutil.DenomUakt, 2500),
+			},
+		},
+	}
+
+	provider.InitGenesis(suite.Context(), suite.ProviderKeeper(), data)
+
+	owner, err := sdk.AccAddressFromBech32(data.Bonds[0].Owner)
+	require.NoError(t, err)
+
+	bond, found := suite.ProviderKeeper().GetBond(suite.Context(), owner)
+	require.True(t, found)
+	require.Equal(t, data.Bonds[0].Amount, bond)
+
+	exported := provider.ExportGenesis(suite.Context(), suite.ProviderKeeper())
+	require.ElementsMatch(t, data.Bonds, exported.Bonds)
+}
+
+func TestGenesisDrains(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+
+	draining := testutil.AccAddress(t).String()
+	maintenance := testutil.AccAddress(t).String()
+
+	data := &types.GenesisState{
+		Params: types.DefaultParams(),
+		Statuses: []types.ProviderStatus{
+			{
+				Owner: draining,
+				State: types.ProviderDraining,
+			},
+			{
+				Owner: maintenance,
+				State: types.ProviderMaintenance,
+			},
+		},
+		Drains: []string{draining},
+	}
+
+	provider.InitGenesis(suite.Context(), suite.ProviderKeeper(), data)
+
+	exported := provider.ExportGenesis(suite.Context(), suite.ProviderKeeper())
+	require.Equal(t, []string{draining}, exported.Drains)
+}
@@ -29,6 +29,22 @@ func NewHandler(keeper keeper.IKeeper, mkeeper mkeeper.IKeeper) baseapp.MsgServi
 			res, err := ms.DeleteProvider(ctx, msg)
 			return sdk.WrapServiceResult(ctx, res, err)
 
+		case *types.MsgBondProvider:
+			res, err := ms.BondProvider(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgUnbondProvider:
+			res, err := ms.UnbondProvider(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgUpdateProviderInventory:
+			res, err := ms.UpdateProviderInventory(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgSetProviderStatus:
+			res, err := ms.SetProviderStatus(ctx, msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
 		default:
 			return nil, sdkerrors.ErrUnknownRequest.Wrapf("unrecognized bank message type: %T", msg)
 		}
@@ -213,6 +213,58 @@ func TestProviderUpdateAttributes(t *testing.T) {
 	require.NotNil(t, res)
 }
 
+func TestProviderAttributeSchema(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	params, err := suite.keeper.GetParams(suite.ctx)
+	require.NoError(t, err)
+
+	params.AttributeSchema = types.AttributeSchema{
+		Strict: true,
+		Definitions: []types.AttributeDefinition{
+			{Key: "region", Type: types.AttributeTypeString},
+			{Key: "tier", Type: types.AttributeTypeEnum, Values: []string{"community", "premium"}},
+			{Key: "capabilities/gpu/vendor/*", Type: types.AttributeTypeBool},
+			{Key: "capabilities/storage/*", Type: types.AttributeTypeQuantity, Units: []string{"Gi", "Ti"}},
+		},
+	}
+	require.NoError(t, suite.keeper.SetParams(suite.ctx, params))
+
+	addr := testutil.AccAddress(t)
+
+	createMsg := &types.MsgCreateProvider{
+		Owner:   addr.String(),
+		HostURI: testutil.ProviderHostname(t),
+		Attributes: akashtypes.Attributes{
+			{Key: "capabilities/gpu/vendor/nvidia/model/a100", Value: "true"},
+			{Key: "capabilities/storage/beta2", Value: "512Gi"},
+			{Key: "region", Value: "us-west"},
+			{Key: "tier", Value: "premium"},
+		},
+	}
+
+	res, err := suite.handler(suite.ctx, createMsg)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	for _, attr := range []akashtypes.Attribute{
+		{Key: "regoin", Value: "us-west"},
+		{Key: "tier", Value: "premuim"},
+		{Key: "capabilities/gpu/vendor/nvidia/model/a100", Value: "yes"},
+		{Key: "capabilities/storage/beta2", Value: "512GB"},
+	} {
+		updateMsg := &types.MsgUpdateProvider{
+			Owner:      addr.String(),
+			HostURI:    createMsg.HostURI,
+			Attributes: akashtypes.Attributes{attr},
+		}
+
+		res, err = suite.handler(suite.ctx, updateMsg)
+		require.ErrorIs(t, err, types.ErrInvalidAttributes, attr.Key)
+		require.Nil(t, res)
+	}
+}
+
 func TestProviderDeleteExisting(t *testing.T) {
 	suite := setupTestSuite(t)
 
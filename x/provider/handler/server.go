@@ -6,6 +6,8 @@ import (
 	errorsmod "cosmossdk.io/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	mv1 "pkg.akt.dev/go/node/market/v1"
 	types "pkg.akt.dev/go/node/provider/v1beta4"
 
 	mkeeper "pkg.akt.dev/node/v2/x/market/keeper"
@@ -43,6 +45,10 @@ func (ms msgServer) CreateProvider(goCtx context.Context, msg *types.MsgCreatePr
 		return nil, types.ErrProviderExists.Wrapf("id: %s", msg.Owner)
 	}
 
+	if err := ms.provider.ValidateAttributes(ctx, msg.Attributes); err != nil {
+		return nil, err
+	}
+
 	if err := ms.provider.Create(ctx, types.Provider(*msg)); err != nil {
 		return nil, ErrInternal.Wrapf("err: %v", err)
 	}
@@ -64,6 +70,10 @@ func (ms msgServer) UpdateProvider(goCtx context.Context, msg *types.MsgUpdatePr
 		return nil, types.ErrProviderNotFound.Wrapf("id: %s", msg.Owner)
 	}
 
+	if err := ms.provider.ValidateAttributes(ctx, msg.Attributes); err != nil {
+		return nil, err
+	}
+
 	if err := ms.provider.Update(ctx, types.Provider(*msg)); err != nil {
 		return nil, errorsmod.Wrapf(ErrInternal, "err: %v", err)
 	}
@@ -86,3 +96,122 @@ func (ms msgServer) DeleteProvider(goCtx context.Context, msg *types.MsgDeletePr
 	// TODO: cancel leases
 	return nil, ErrInternal.Wrap("NOTIMPLEMENTED")
 }
+
+func (ms msgServer) BondProvider(goCtx context.Context, msg *types.MsgBondProvider) (*types.MsgBondProviderResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	owner, err := sdk.AccAddressFromBech32(msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ms.provider.Get(ctx, owner); !ok {
+		return nil, types.ErrProviderNotFound.Wrapf("id: %s", msg.Owner)
+	}
+
+	if err := ms.provider.Bond(ctx, owner, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgBondProviderResponse{}, nil
+}
+
+func (ms msgServer) UnbondProvider(goCtx context.Context, msg *types.MsgUnbondProvider) (*types.MsgUnbondProviderResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	owner, err := sdk.AccAddressFromBech32(msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := ms.provider.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bond, found := ms.provider.GetBond(ctx, owner)
+	if !found {
+		return nil, types.ErrInsufficientBond
+	}
+
+	// bond backing active leases may not be reduced below minimum bond
+	if bond.Denom == params.MinBond.Denom &&
+		bond.Amount.Sub(msg.Amount.Amount).LT(params.MinBond.Amount) &&
+		ms.hasActiveLeases(ctx, msg.Owner) {
+		return nil, types.ErrInsufficientBond.Wrapf("provider with active leases must keep bond of %s", params.MinBond)
+	}
+
+	if _, err := ms.provider.Unbond(ctx, owner, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUnbondProviderResponse{}, nil
+}
+
+func (ms msgServer) UpdateProviderInventory(goCtx context.Context, msg *types.MsgUpdateProviderInventory) (*types.MsgUpdateProviderInventoryResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	owner, err := sdk.AccAddressFromBech32(msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ms.provider.Get(ctx, owner); !ok {
+		return nil, types.ErrProviderNotFound.Wrapf("id: %s", msg.Owner)
+	}
+
+	if err := ms.provider.UpdateInventory(ctx, owner, msg.Inventory, msg.Timestamp); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateProviderInventoryResponse{}, nil
+}
+
+func (ms msgServer) SetProviderStatus(goCtx context.Context, msg *types.MsgSetProviderStatus) (*types.MsgSetProviderStatusResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	owner, err := sdk.AccAddressFromBech32(msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ms.provider.Get(ctx, owner); !ok {
+		return nil, types.ErrProviderNotFound.Wrapf("id: %s", msg.Owner)
+	}
+
+	status := types.ProviderStatus{
+		State:   msg.State,
+		Reason:  msg.Reason,
+		Windows: msg.Windows,
+	}
+
+	if err := ms.provider.SetStatus(ctx, owner, status, msg.ReclaimLeases); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetProviderStatusResponse{}, nil
+}
+
+func (ms msgServer) hasActiveLeases(ctx sdk.Context, provider string) bool {
+	active := false
+
+	ms.market.WithLeasesForProvider(ctx, provider, func(lease mv1.Lease) bool {
+		active = lease.State == mv1.LeaseActive || lease.State == mv1.LeaseReclaiming
+		return active
+	})
+
+	return active
+}
+
+func (ms msgServer) UpdateParams(goCtx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if ms.provider.GetAuthority() != req.Authority {
+		return nil, govtypes.ErrInvalidSigner.Wrapf("invalid authority; expected %s, got %s", ms.provider.GetAuthority(), req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := ms.provider.SetParams(ctx, req.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
@@ -0,0 +1,184 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+)
+
+// GetBond returns bond of the provider with given id
+func (k Keeper) GetBond(ctx sdk.Context, id sdk.AccAddress) (sdk.Coin, bool) {
+	bond, err := k.bonds.Get(ctx, id)
+	if err != nil {
+		return sdk.Coin{}, false
+	}
+
+	return bond, true
+}
+
+// SetBond replaces bond of the provider without moving funds, funds are expected
+// to be held by the provider module account already. Used by genesis.
+func (k Keeper) SetBond(ctx sdk.Context, id sdk.AccAddress, bond sdk.Coin) error {
+	if !bond.IsValid() {
+		return fmt.Errorf("%w: %s", types.ErrInvalidBond, bond)
+	}
+
+	return k.setBond(ctx, id, bond)
+}
+
+// WithBonds iterates bonds of all providers
+func (k Keeper) WithBonds(ctx sdk.Context, fn func(sdk.AccAddress, sdk.Coin) bool) {
+	err := k.bonds.Walk(ctx, nil, func(id sdk.AccAddress, bond sdk.Coin) (bool, error) {
+		return fn(id, bond), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Bond transfers amount from provider's account into its bond
+func (k Keeper) Bond(ctx sdk.Context, id sdk.AccAddress, amount sdk.Coin) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !amount.IsPositive() || amount.Denom != params.MinBond.Denom {
+		return fmt.Errorf("%w: bond must be positive amount of %s", types.ErrInvalidBond, params.MinBond.Denom)
+	}
+
+	bond, found := k.GetBond(ctx, id)
+	if !found {
+		bond = sdk.NewCoin(amount.Denom, sdkmath.ZeroInt())
+	}
+
+	if bond.Denom != amount.Denom {
+		return fmt.Errorf("%w: existing bond is in %s", types.ErrInvalidBond, bond.Denom)
+	}
+
+	if err := k.bkeeper.SendCoinsFromAccountToModule(ctx, id, types.ModuleName, sdk.NewCoins(amount)); err != nil {
+		return err
+	}
+
+	bond = bond.Add(amount)
+
+	if err := k.bonds.Set(ctx, id, bond); err != nil {
+		return err
+	}
+
+	return ctx.EventManager().EmitTypedEvent(
+		&types.EventProviderBonded{
+			Owner:  id.String(),
+			Amount: amount,
+		},
+	)
+}
+
+// Unbond returns amount of the bond to the provider's account. It returns remaining bond.
+func (k Keeper) Unbond(ctx sdk.Context, id sdk.AccAddress, amount sdk.Coin) (sdk.Coin, error) {
+	bond, found := k.GetBond(ctx, id)
+	if !found {
+		return sdk.Coin{}, types.ErrInsufficientBond
+	}
+
+	if !amount.IsPositive() || amount.Denom != bond.Denom {
+		return sdk.Coin{}, fmt.Errorf("%w: unbond must be positive amount of %s", types.ErrInvalidBond, bond.Denom)
+	}
+
+	if bond.IsLT(amount) {
+		return sdk.Coin{}, fmt.Errorf("%w: bonded %s", types.ErrInsufficientBond, bond)
+	}
+
+	if err := k.bkeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, id, sdk.NewCoins(amount)); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	bond = bond.Sub(amount)
+
+	if err := k.setBond(ctx, id, bond); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	err := ctx.EventManager().EmitTypedEvent(
+		&types.EventProviderUnbonded{
+			Owner:  id.String(),
+			Amount: amount,
+		},
+	)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return bond, nil
+}
+
+// Slash transfers fraction of the provider's bond to the recipient. It returns slashed amount,
+// which is empty for provider without bond.
+func (k Keeper) Slash(ctx sdk.Context, id sdk.AccAddress, fraction sdkmath.LegacyDec, recipient sdk.AccAddress) (sdk.Coins, error) {
+	bond, found := k.GetBond(ctx, id)
+	if !found || !fraction.IsPositive() {
+		return sdk.Coins{}, nil
+	}
+
+	if fraction.GT(sdkmath.LegacyOneDec()) {
+		fraction = sdkmath.LegacyOneDec()
+	}
+
+	amount := sdk.NewCoin(bond.Denom, fraction.MulInt(bond.Amount).TruncateInt())
+	if amount.IsZero() {
+		return sdk.Coins{}, nil
+	}
+
+	if err := k.bkeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, sdk.NewCoins(amount)); err != nil {
+		return nil, err
+	}
+
+	if err := k.setBond(ctx, id, bond.Sub(amount)); err != nil {
+		return nil, err
+	}
+
+	err := ctx.EventManager().EmitTypedEvent(
+		&types.EventProviderSlashed{
+			Owner:     id.String(),
+			Recipient: recipient.String(),
+			Amount:    amount,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.NewCoins(amount), nil
+}
+
+// ValidateBidBond checks that provider has at least minimum bond when bid price
+// is above the threshold set for price denomination
+func (k Keeper) ValidateBidBond(ctx sdk.Context, id sdk.AccAddress, price sdk.DecCoin) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	threshold := params.BidBondThreshold.AmountOf(price.Denom)
+	if !threshold.IsPositive() || price.Amount.LTE(threshold) {
+		return nil
+	}
+
+	bond, found := k.GetBond(ctx, id)
+	if !found || bond.Denom != params.MinBond.Denom || bond.IsLT(params.MinBond) {
+		return fmt.Errorf("%w: bids above %s%s require bond of %s", types.ErrInsufficientBond, threshold, price.Denom, params.MinBond)
+	}
+
+	return nil
+}
+
+func (k Keeper) setBond(ctx sdk.Context, id sdk.AccAddress, bond sdk.Coin) error {
+	if bond.IsZero() {
+		return k.bonds.Remove(ctx, id)
+	}
+
+	return k.bonds.Set(ctx, id, bond)
+}
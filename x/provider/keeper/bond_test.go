@@ -0,0 +1,96 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+	"pkg.akt.dev/go/sdkutil"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/testutil/state"
+)
+
+func TestProviderBond(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx := suite.Context()
+	keeper := suite.ProviderKeeper()
+
+	params, err := keeper.GetParams(ctx)
+	require.NoError(t, err)
+
+	params.MinBond = sdk.NewInt64Coin(sdkutil.DenomUakt, 1000)
+	params.BidBondThreshold = sdk.NewDecCoins(sdk.NewInt64DecCoin(sdkutil.DenomUact, 100))
+	require.NoError(t, keeper.SetParams(ctx, params))
+
+	owner := testutil.AccAddress(t)
+	tenant := testutil.AccAddress(t)
+
+	price := sdk.NewInt64DecCoin(sdkutil.DenomUact, 200)
+
+	// no bond required for bids up to the threshold
+	require.NoError(t, keeper.ValidateBidBond(ctx, owner, sdk.NewInt64DecCoin(sdkutil.DenomUact, 100)))
+	require.ErrorIs(t, keeper.ValidateBidBond(ctx, owner, price), types.ErrInsufficientBond)
+
+	bond := sdk.NewInt64Coin(sdkutil.DenomUakt, 1000)
+
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		ts.BankKeeper().
+			On("SendCoinsFromAccountToModule", mock.Anything, owner, types.ModuleName, sdk.NewCoins(bond)).
+			Return(nil).Once()
+	})
+
+	require.ErrorIs(t, keeper.Bond(ctx, owner, sdk.NewInt64Coin(sdkutil.DenomUact, 1000)), types.ErrInvalidBond)
+	require.NoError(t, keeper.Bond(ctx, owner, bond))
+
+	testutil.EnsureEvent(t, ctx.EventManager().ABCIEvents(), &types.EventProviderBonded{
+		Owner:  owner.String(),
+		Amount: bond,
+	})
+
+	require.NoError(t, keeper.ValidateBidBond(ctx, owner, price))
+
+	slashed := sdk.NewInt64Coin(sdkutil.DenomUakt, 250)
+
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		ts.BankKeeper().
+			On("SendCoinsFromModuleToAccount", mock.Anything, types.ModuleName, tenant, sdk.NewCoins(slashed)).
+			Return(nil).Once()
+	})
+
+	amount, err := keeper.Slash(ctx, owner, sdkmath.LegacyNewDecWithPrec(25, 2), tenant)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(slashed), amount)
+
+	remaining, found := keeper.GetBond(ctx, owner)
+	require.True(t, found)
+	require.Equal(t, sdk.NewInt64Coin(sdkutil.DenomUakt, 750), remaining)
+
+	// slashed bond is below minimum
+	require.ErrorIs(t, keeper.ValidateBidBond(ctx, owner, price), types.ErrInsufficientBond)
+
+	_, err = keeper.Unbond(ctx, owner, sdk.NewInt64Coin(sdkutil.DenomUakt, 1000))
+	require.ErrorIs(t, err, types.ErrInsufficientBond)
+
+	suite.PrepareMocks(func(ts *state.TestSuite) {
+		ts.BankKeeper().
+			On("SendCoinsFromModuleToAccount", mock.Anything, types.ModuleName, owner, sdk.NewCoins(remaining)).
+			Return(nil).Once()
+	})
+
+	remaining, err = keeper.Unbond(ctx, owner, remaining)
+	require.NoError(t, err)
+	require.True(t, remaining.IsZero())
+
+	_, found = keeper.GetBond(ctx, owner)
+	require.False(t, found)
+
+	// provider without bond is not slashed
+	amount, err = keeper.Slash(ctx, owner, sdkmath.LegacyOneDec(), tenant)
+	require.NoError(t, err)
+	require.True(t, amount.IsZero())
+}
@@ -3,6 +3,8 @@ package keeper
 import (
 	"context"
 
+	"cosmossdk.io/store/prefix"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -25,21 +27,40 @@ func (k Querier) Providers(c context.Context, req *types.QueryProvidersRequest)
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
 
+	var state types.ProviderState
+	if req.State != "" {
+		state = types.ProviderState(types.ProviderState_value[req.State])
+		if state == types.ProviderStateInvalid {
+			return nil, status.Error(codes.InvalidArgument, "invalid state value")
+		}
+	}
+
 	var providers types.Providers
 	ctx := sdk.UnwrapSDKContext(c)
 
-	store := ctx.KVStore(k.skey)
+	store := prefix.NewStore(ctx.KVStore(k.skey), types.ProviderPrefix())
 
-	pageRes, err := sdkquery.Paginate(store, req.Pagination, func(_ []byte, value []byte) error {
+	pageRes, err := sdkquery.FilteredPaginate(store, req.Pagination, func(_ []byte, value []byte, accumulate bool) (bool, error) {
 		var provider types.Provider
 
 		err := k.cdc.Unmarshal(value, &provider)
 		if err != nil {
-			return err
+			return false, err
+		}
+
+		if state != types.ProviderStateInvalid && !k.hasState(ctx, provider.Owner, state) {
+			return false, nil
 		}
 
-		providers = append(providers, provider)
-		return nil
+		if req.Capacity != nil && !k.hasCapacity(ctx, provider.Owner, *req.Capacity) {
+			return false, nil
+		}
+
+		if accumulate {
+			providers = append(providers, provider)
+		}
+
+		return true, nil
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -71,3 +92,112 @@ func (k Querier) Provider(c context.Context, req *types.QueryProviderRequest) (*
 
 	return &types.QueryProviderResponse{Provider: provider}, nil
 }
+
+// ProviderBond returns bond of the provider based on owner address
+func (k Querier) ProviderBond(c context.Context, req *types.QueryProviderBondRequest) (*types.QueryProviderBondResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	owner, err := sdk.AccAddressFromBech32(req.Owner)
+	if err != nil {
+		return nil, types.ErrInvalidAddress
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	bond, found := k.GetBond(ctx, owner)
+	if !found {
+		return nil, status.Error(codes.NotFound, "provider has no bond")
+	}
+
+	return &types.QueryProviderBondResponse{Bond: bond}, nil
+}
+
+// ProviderInventory returns capacity inventory published by the provider
+func (k Querier) ProviderInventory(c context.Context, req *types.QueryProviderInventoryRequest) (*types.QueryProviderInventoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	owner, err := sdk.AccAddressFromBech32(req.Owner)
+	if err != nil {
+		return nil, types.ErrInvalidAddress
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	inv, found := k.GetInventory(ctx, owner)
+	if !found {
+		return nil, status.Error(codes.NotFound, "provider has not published inventory")
+	}
+
+	return &types.QueryProviderInventoryResponse{
+		Inventory: inv,
+		Stale:     k.IsInventoryStale(ctx, inv),
+	}, nil
+}
+
+// ProviderStatus returns status of the provider along with its state at current block time
+func (k Querier) ProviderStatus(c context.Context, req *types.QueryProviderStatusRequest) (*types.QueryProviderStatusResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	owner, err := sdk.AccAddressFromBech32(req.Owner)
+	if err != nil {
+		return nil, types.ErrInvalidAddress
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if _, found := k.Get(ctx, owner); !found {
+		return nil, types.ErrProviderNotFound
+	}
+
+	pstatus, _ := k.GetStatus(ctx, owner)
+
+	return &types.QueryProviderStatusResponse{
+		Status:         pstatus,
+		EffectiveState: k.EffectiveState(ctx, owner),
+	}, nil
+}
+
+// hasState checks provider is in given state at current block time
+func (k Querier) hasState(ctx sdk.Context, owner string, state types.ProviderState) bool {
+	addr, err := sdk.AccAddressFromBech32(owner)
+	if err != nil {
+		return false
+	}
+
+	return k.EffectiveState(ctx, addr) == state
+}
+
+// hasCapacity checks provider has published inventory which is not stale and satisfies the filter
+func (k Querier) hasCapacity(ctx sdk.Context, owner string, filter types.CapacityFilter) bool {
+	addr, err := sdk.AccAddressFromBech32(owner)
+	if err != nil {
+		return false
+	}
+
+	inv, found := k.GetInventory(ctx, addr)
+	if !found || k.IsInventoryStale(ctx, inv) {
+		return false
+	}
+
+	return InventoryHasCapacity(inv.Inventory, filter)
+}
+
+func (k Querier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "unable to retrieve params: %s", err.Error())
+	}
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
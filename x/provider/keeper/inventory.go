@@ -0,0 +1,124 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+)
+
+// GetInventory returns last capacity inventory published by the provider
+func (k Keeper) GetInventory(ctx sdk.Context, id sdk.AccAddress) (types.ProviderInventory, bool) {
+	inv, err := k.inventories.Get(ctx, id)
+	if err != nil {
+		return types.ProviderInventory{}, false
+	}
+
+	return inv, true
+}
+
+// UpdateInventory replaces provider's capacity inventory. Updates are accepted no more often than
+// inventory_update_interval, and inventory timestamp must not be in the future nor older than previous one.
+func (k Keeper) UpdateInventory(ctx sdk.Context, id sdk.AccAddress, inventory types.Inventory, timestamp time.Time) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := inventory.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInvalidInventory, err.Error())
+	}
+
+	if timestamp.After(ctx.BlockTime()) {
+		return fmt.Errorf("%w: timestamp %s is after current block time", types.ErrInvalidInventory, timestamp.UTC())
+	}
+
+	prev, found := k.GetInventory(ctx, id)
+	if found {
+		if !timestamp.After(prev.Timestamp) {
+			return fmt.Errorf("%w: timestamp %s is not after previous one", types.ErrInvalidInventory, timestamp.UTC())
+		}
+
+		if next := prev.UpdatedAt.Add(params.InventoryUpdateInterval); ctx.BlockTime().Before(next) {
+			return fmt.Errorf("%w: next update allowed at %s", types.ErrInventoryRateLimited, next.UTC())
+		}
+	}
+
+	inv := types.ProviderInventory{
+		Owner:     id.String(),
+		Inventory: inventory,
+		Timestamp: timestamp.UTC(),
+		UpdatedAt: ctx.BlockTime().UTC(),
+	}
+
+	if err := k.inventories.Set(ctx, id, inv); err != nil {
+		return err
+	}
+
+	return ctx.EventManager().EmitTypedEvent(
+		&types.EventProviderInventoryUpdated{
+			Owner:     id.String(),
+			Timestamp: inv.Timestamp,
+		},
+	)
+}
+
+// IsInventoryStale returns true if inventory has not been refreshed within inventory_stale_after
+func (k Keeper) IsInventoryStale(ctx sdk.Context, inv types.ProviderInventory) bool {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return true
+	}
+
+	return ctx.BlockTime().Sub(inv.Timestamp) > params.InventoryStaleAfter
+}
+
+// WithInventories iterates all published inventories
+func (k Keeper) WithInventories(ctx sdk.Context, fn func(types.ProviderInventory) bool) {
+	err := k.inventories.Walk(ctx, nil, func(_ sdk.AccAddress, inv types.ProviderInventory) (bool, error) {
+		return fn(inv), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// InventoryHasCapacity checks inventory has at least capacity requested by the filter available
+func InventoryHasCapacity(inv types.Inventory, filter types.CapacityFilter) bool {
+	if inv.CPU.Available < filter.CPU || inv.Memory.Available < filter.Memory {
+		return false
+	}
+
+	if filter.Storage > 0 {
+		found := false
+
+		for _, storage := range inv.Storage {
+			if (filter.StorageClass == "" || storage.Class == filter.StorageClass) && storage.Capacity.Available >= filter.Storage {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if filter.GPU > 0 {
+		var available uint64
+
+		for _, gpu := range inv.GPU {
+			if (filter.GPUVendor == "" || gpu.Vendor == filter.GPUVendor) && (filter.GPUModel == "" || gpu.Model == filter.GPUModel) {
+				available += gpu.Capacity.Available
+			}
+		}
+
+		if available < filter.GPU {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+	"pkg.akt.dev/go/testutil"
+
+	"pkg.akt.dev/node/v2/x/provider/keeper"
+)
+
+func TestProviderInventory(t *testing.T) {
+	ctx, pkeeper := setupKeeper(t)
+
+	params, err := pkeeper.GetParams(ctx)
+	require.NoError(t, err)
+
+	params.InventoryUpdateInterval = time.Minute
+	params.InventoryStaleAfter = time.Hour
+	require.NoError(t, pkeeper.SetParams(ctx, params))
+
+	owner := testutil.AccAddress(t)
+	now := ctx.BlockTime()
+
+	inventory := types.Inventory{
+		CPU:    types.ResourceCapacity{Total: 64000, Available: 32000},
+		Memory: types.ResourceCapacity{Total: 256 << 30, Available: 128 << 30},
+		Storage: []types.StorageCapacity{
+			{Class: "beta3", Capacity: types.ResourceCapacity{Total: 4 << 40, Available: 1 << 40}},
+		},
+		GPU: []types.GPUCapacity{
+			{Vendor: "nvidia", Model: "a100", Capacity: types.ResourceCapacity{Total: 8, Available: 4}},
+		},
+	}
+
+	err = pkeeper.UpdateInventory(ctx, owner, inventory, now.Add(time.Second))
+	require.ErrorIs(t, err, types.ErrInvalidInventory)
+
+	require.NoError(t, pkeeper.UpdateInventory(ctx, owner, inventory, now))
+
+	inv, found := pkeeper.GetInventory(ctx, owner)
+	require.True(t, found)
+	require.Equal(t, inventory, inv.Inventory)
+	require.False(t, pkeeper.IsInventoryStale(ctx, inv))
+
+	ctx = ctx.WithBlockTime(now.Add(30 * time.Second))
+
+	err = pkeeper.UpdateInventory(ctx, owner, inventory, ctx.BlockTime())
+	require.ErrorIs(t, err, types.ErrInventoryRateLimited)
+
+	ctx = ctx.WithBlockTime(now.Add(2 * time.Hour))
+	require.True(t, pkeeper.IsInventoryStale(ctx, inv))
+
+	require.True(t, keeper.InventoryHasCapacity(inv.Inventory, types.CapacityFilter{CPU: 16000, GPU: 4, GPUModel: "a100"}))
+	require.True(t, keeper.InventoryHasCapacity(inv.Inventory, types.CapacityFilter{Storage: 1 << 40, StorageClass: "beta3"}))
+	require.False(t, keeper.InventoryHasCapacity(inv.Inventory, types.CapacityFilter{Storage: 1 << 40, StorageClass: "beta2"}))
+	require.False(t, keeper.InventoryHasCapacity(inv.Inventory, types.CapacityFilter{GPU: 5}))
+}
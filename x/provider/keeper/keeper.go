@@ -1,12 +1,20 @@
 package keeper
 
 import (
+	"time"
+
+	"cosmossdk.io/collections"
+	sdkmath "cosmossdk.io/math"
 	"cosmossdk.io/store/prefix"
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	types "pkg.akt.dev/go/node/provider/v1beta4"
+	attrv1 "pkg.akt.dev/go/node/types/attributes/v1"
+
+	"pkg.akt.dev/node/v2/x/provider/config"
 )
 
 type IKeeper interface {
@@ -17,20 +25,79 @@ type IKeeper interface {
 	WithProviders(ctx sdk.Context, fn func(types.Provider) bool)
 	Update(ctx sdk.Context, provider types.Provider) error
 	Delete(ctx sdk.Context, id sdk.Address)
+	GetBond(ctx sdk.Context, id sdk.AccAddress) (sdk.Coin, bool)
+	SetBond(ctx sdk.Context, id sdk.AccAddress, bond sdk.Coin) error
+	WithBonds(ctx sdk.Context, fn func(sdk.AccAddress, sdk.Coin) bool)
+	Bond(ctx sdk.Context, id sdk.AccAddress, amount sdk.Coin) error
+	Unbond(ctx sdk.Context, id sdk.AccAddress, amount sdk.Coin) (sdk.Coin, error)
+	Slash(ctx sdk.Context, id sdk.AccAddress, fraction sdkmath.LegacyDec, recipient sdk.AccAddress) (sdk.Coins, error)
+	ValidateBidBond(ctx sdk.Context, id sdk.AccAddress, price sdk.DecCoin) error
+	GetParams(ctx sdk.Context) (types.Params, error)
+	SetParams(ctx sdk.Context, params types.Params) error
+	GetAuthority() string
+	ValidateAttributes(ctx sdk.Context, attrs attrv1.Attributes) error
+	GetInventory(ctx sdk.Context, id sdk.AccAddress) (types.ProviderInventory, bool)
+	UpdateInventory(ctx sdk.Context, id sdk.AccAddress, inventory types.Inventory, timestamp time.Time) error
+	IsInventoryStale(ctx sdk.Context, inv types.ProviderInventory) bool
+	WithInventories(ctx sdk.Context, fn func(types.ProviderInventory) bool)
+	GetStatus(ctx sdk.Context, id sdk.AccAddress) (types.ProviderStatus, bool)
+	SetStatus(ctx sdk.Context, id sdk.AccAddress, status types.ProviderStatus, reclaim bool) error
+	EffectiveState(ctx sdk.Context, id sdk.AccAddress) types.ProviderState
+	WithDrainingProviders(ctx sdk.Context, fn func(sdk.AccAddress) bool)
+	OnDrainCompleted(ctx sdk.Context, id sdk.AccAddress) error
+	OnDrainFailed(ctx sdk.Context, id sdk.AccAddress) (uint32, error)
 	NewQuerier() Querier
 }
 
 // Keeper of the provider store
 type Keeper struct {
-	skey storetypes.StoreKey
-	cdc  codec.BinaryCodec
+	skey    storetypes.StoreKey
+	cdc     codec.BinaryCodec
+	bkeeper BankKeeper
+	// The address capable of executing a MsgUpdateParams message.
+	// This should be the x/gov module account.
+	authority string
+
+	params collections.Item[types.Params]
+	// bonds holds provider-level collateral, escrowed in the provider module account
+	bonds collections.Map[sdk.AccAddress, sdk.Coin]
+	// inventories holds capacity last published by providers
+	inventories collections.Map[sdk.AccAddress, types.ProviderInventory]
+	// statuses holds provider status and maintenance windows
+	statuses collections.Map[sdk.AccAddress, types.ProviderStatus]
+	// drains holds draining providers which leases are yet to be reclaimed
+	drains collections.KeySet[sdk.AccAddress]
+	// drainRetries counts reclamation passes of draining providers which left leases unreclaimed.
+	// It is not part of genesis, retries of imported drains start over.
+	drainRetries collections.Map[sdk.AccAddress, uint32]
 }
 
 // NewKeeper creates and returns an instance for Provider keeper
-func NewKeeper(cdc codec.BinaryCodec, skey storetypes.StoreKey) IKeeper {
+func NewKeeper(cdc codec.BinaryCodec, skey *storetypes.KVStoreKey, bkeeper BankKeeper, authority string) IKeeper {
+	sb := collections.NewSchemaBuilder(runtime.NewKVStoreService(skey))
+
+	params := collections.NewItem(sb, collections.NewPrefix(ParamsPrefix), "params", codec.CollValue[types.Params](cdc))
+	bonds := collections.NewMap(sb, collections.NewPrefix(BondPrefix), "bonds", sdk.AccAddressKey, codec.CollValue[sdk.Coin](cdc))
+	inventories := collections.NewMap(sb, collections.NewPrefix(InventoryPrefix), "inventories", sdk.AccAddressKey, codec.CollValue[types.ProviderInventory](cdc))
+	statuses := collections.NewMap(sb, collections.NewPrefix(StatusPrefix), "statuses", sdk.AccAddressKey, codec.CollValue[types.ProviderStatus](cdc))
+	drains := collections.NewKeySet(sb, collections.NewPrefix(DrainPrefix), "drains", sdk.AccAddressKey)
+	drainRetries := collections.NewMap(sb, collections.NewPrefix(DrainRetryPrefix), "drain_retries", sdk.AccAddressKey, collections.Uint32Value)
+
+	if _, err := sb.Build(); err != nil {
+		panic(err)
+	}
+
 	return Keeper{
-		skey: skey,
-		cdc:  cdc,
+		skey:         skey,
+		cdc:          cdc,
+		bkeeper:      bkeeper,
+		authority:    authority,
+		params:       params,
+		bonds:        bonds,
+		inventories:  inventories,
+		statuses:     statuses,
+		drains:       drains,
+		drainRetries: drainRetries,
 	}
 }
 
@@ -48,6 +115,39 @@ func (k Keeper) StoreKey() storetypes.StoreKey {
 	return k.skey
 }
 
+// GetAuthority returns the x/provider module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// SetParams sets the x/provider module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, p types.Params) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	return k.params.Set(ctx, p)
+}
+
+// GetParams returns the current x/provider module parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (types.Params, error) {
+	return k.params.Get(ctx)
+}
+
+// ValidateAttributes checks provider attributes against the attribute schema in module parameters
+func (k Keeper) ValidateAttributes(ctx sdk.Context, attrs attrv1.Attributes) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := config.ValidateAttributes(params.AttributeSchema, attrs); err != nil {
+		return types.ErrInvalidAttributes.Wrap(err.Error())
+	}
+
+	return nil
+}
+
 // Get returns a provider with given provider id
 func (k Keeper) Get(ctx sdk.Context, id sdk.Address) (types.Provider, bool) {
 	store := ctx.KVStore(k.skey)
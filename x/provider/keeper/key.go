@@ -8,6 +8,15 @@ import (
 	types "pkg.akt.dev/go/node/provider/v1beta4"
 )
 
+var (
+	ParamsPrefix     = []byte{0x11, 0x00}
+	BondPrefix       = []byte{0x12, 0x00}
+	InventoryPrefix  = []byte{0x13, 0x00}
+	StatusPrefix     = []byte{0x14, 0x00}
+	DrainPrefix      = []byte{0x15, 0x00}
+	DrainRetryPrefix = []byte{0x15, 0x01}
+)
+
 func ProviderKey(id sdk.Address) []byte {
 	buf := bytes.NewBuffer(types.ProviderPrefix())
 	buf.Write(address.MustLengthPrefix(id.Bytes()))
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/collections"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+)
+
+// GetStatus returns status set by the provider
+func (k Keeper) GetStatus(ctx sdk.Context, id sdk.AccAddress) (types.ProviderStatus, bool) {
+	status, err := k.statuses.Get(ctx, id)
+	if err != nil {
+		return types.ProviderStatus{}, false
+	}
+
+	return status, true
+}
+
+// SetStatus replaces provider status. Maintenance windows which have already ended are dropped.
+// If reclaim is set on draining status, provider is queued for its reclaimable leases to be reclaimed.
+func (k Keeper) SetStatus(ctx sdk.Context, id sdk.AccAddress, status types.ProviderStatus, reclaim bool) error {
+	if status.State == types.ProviderStateInvalid {
+		return fmt.Errorf("%w: state must be set", types.ErrInvalidProviderStatus)
+	}
+
+	windows := make([]types.MaintenanceWindow, 0, len(status.Windows))
+
+	for _, window := range status.Windows {
+		if !window.End.After(window.Start) {
+			return fmt.Errorf("%w: maintenance window must end after it starts", types.ErrInvalidProviderStatus)
+		}
+
+		if window.End.After(ctx.BlockTime()) {
+			windows = append(windows, window)
+		}
+	}
+
+	status.Owner = id.String()
+	status.Windows = windows
+	status.UpdatedAt = ctx.BlockTime().UTC()
+
+	if err := k.statuses.Set(ctx, id, status); err != nil {
+		return err
+	}
+
+	var err error
+	if status.State == types.ProviderDraining && reclaim {
+		err = k.drains.Set(ctx, id)
+	} else {
+		err = k.drains.Remove(ctx, id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := k.drainRetries.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return ctx.EventManager().EmitTypedEvent(
+		&types.EventProviderStatusChanged{
+			Owner:  id.String(),
+			State:  status.State,
+			Reason: status.Reason,
+		},
+	)
+}
+
+// EffectiveState returns state of the provider at current block time. Provider without status
+// is accepting, accepting provider is in maintenance during any of its maintenance windows.
+func (k Keeper) EffectiveState(ctx sdk.Context, id sdk.AccAddress) types.ProviderState {
+	status, found := k.GetStatus(ctx, id)
+	if !found {
+		return types.ProviderAccepting
+	}
+
+	if status.State != types.ProviderAccepting {
+		return status.State
+	}
+
+	now := ctx.BlockTime()
+
+	for _, window := range status.Windows {
+		if !now.Before(window.Start) && now.Before(window.End) {
+			return types.ProviderMaintenance
+		}
+	}
+
+	return types.ProviderAccepting
+}
+
+// WithDrainingProviders iterates providers queued for reclamation of their leases
+func (k Keeper) WithDrainingProviders(ctx sdk.Context, fn func(sdk.AccAddress) bool) {
+	err := k.drains.Walk(ctx, nil, func(id sdk.AccAddress) (bool, error) {
+		return fn(id), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// OnDrainCompleted removes provider from the reclamation queue once all its reclaimable leases are reclaiming
+func (k Keeper) OnDrainCompleted(ctx sdk.Context, id sdk.AccAddress) error {
+	if err := k.drainRetries.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return k.drains.Remove(ctx, id)
+}
+
+// OnDrainFailed records reclamation pass of the draining provider which left some of its leases
+// unreclaimed and returns number of such passes so far. Provider stays queued.
+func (k Keeper) OnDrainFailed(ctx sdk.Context, id sdk.AccAddress) (uint32, error) {
+	retries, err := k.drainRetries.Get(ctx, id)
+	if err != nil && !errors.Is(err, collections.ErrNotFound) {
+		return 0, err
+	}
+
+	retries++
+
+	if err := k.drainRetries.Set(ctx, id, retries); err != nil {
+		return 0, err
+	}
+
+	return retries, nil
+}
@@ -0,0 +1,96 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	types "pkg.akt.dev/go/node/provider/v1beta4"
+	"pkg.akt.dev/go/testutil"
+)
+
+func TestProviderStatus(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+	now := ctx.BlockTime()
+
+	// provider without status accepts workloads
+	require.Equal(t, types.ProviderAccepting, keeper.EffectiveState(ctx, owner))
+
+	err := keeper.SetStatus(ctx, owner, types.ProviderStatus{
+		State: types.ProviderAccepting,
+		Windows: []types.MaintenanceWindow{
+			{Start: now.Add(time.Hour), End: now},
+		},
+	}, false)
+	require.ErrorIs(t, err, types.ErrInvalidProviderStatus)
+
+	err = keeper.SetStatus(ctx, owner, types.ProviderStatus{
+		State: types.ProviderAccepting,
+		Windows: []types.MaintenanceWindow{
+			{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)},
+			{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+		},
+	}, false)
+	require.NoError(t, err)
+
+	status, found := keeper.GetStatus(ctx, owner)
+	require.True(t, found)
+	require.Len(t, status.Windows, 1)
+
+	require.Equal(t, types.ProviderAccepting, keeper.EffectiveState(ctx, owner))
+	require.Equal(t, types.ProviderMaintenance, keeper.EffectiveState(ctx.WithBlockTime(now.Add(time.Hour)), owner))
+	require.Equal(t, types.ProviderAccepting, keeper.EffectiveState(ctx.WithBlockTime(now.Add(2*time.Hour)), owner))
+
+	err = keeper.SetStatus(ctx, owner, types.ProviderStatus{State: types.ProviderDraining}, true)
+	require.NoError(t, err)
+	require.Equal(t, types.ProviderDraining, keeper.EffectiveState(ctx, owner))
+
+	var draining []string
+	keeper.WithDrainingProviders(ctx, func(id sdk.AccAddress) bool {
+		draining = append(draining, id.String())
+		return false
+	})
+	require.Equal(t, []string{owner.String()}, draining)
+
+	require.NoError(t, keeper.OnDrainCompleted(ctx, owner))
+
+	draining = nil
+	keeper.WithDrainingProviders(ctx, func(id sdk.AccAddress) bool {
+		draining = append(draining, id.String())
+		return false
+	})
+	require.Empty(t, draining)
+}
+
+func TestProviderDrainRetries(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+
+	owner := testutil.AccAddress(t)
+
+	err := keeper.SetStatus(ctx, owner, types.ProviderStatus{State: types.ProviderDraining}, true)
+	require.NoError(t, err)
+
+	for i := uint32(1); i <= 3; i++ {
+		retries, err := keeper.OnDrainFailed(ctx, owner)
+		require.NoError(t, err)
+		require.Equal(t, i, retries)
+	}
+
+	// requeueing provider starts retries over
+	err = keeper.SetStatus(ctx, owner, types.ProviderStatus{State: types.ProviderDraining}, true)
+	require.NoError(t, err)
+
+	retries, err := keeper.OnDrainFailed(ctx, owner)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), retries)
+
+	require.NoError(t, keeper.OnDrainCompleted(ctx, owner))
+
+	retries, err = keeper.OnDrainFailed(ctx, owner)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), retries)
+}
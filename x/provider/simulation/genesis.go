@@ -8,7 +8,9 @@ import (
 
 // RandomizedGenState generates a random GenesisState for supply
 func RandomizedGenState(simState *module.SimulationState) {
-	providerGenesis := &types.GenesisState{}
+	providerGenesis := &types.GenesisState{
+		Params: types.DefaultParams(),
+	}
 
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(providerGenesis)
 }